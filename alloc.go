@@ -0,0 +1,34 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// WithAllocLimit returns a Middleware that fails a test case whose run makes more than n heap allocations. Like
+// testing.AllocsPerRun, the allocations are counted with GOMAXPROCS set to 1, so that other goroutines do not
+// add to the count. The count includes the few allocations made to call the test function.
+//
+//	test.Use(tbltest.WithAllocLimit(8)).Run(func(tc testcase) bool { ... })
+func WithAllocLimit(n uint64) Middleware {
+	return func(next CaseFunc) CaseFunc {
+		return func(idx int, tc TestCase) error {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			err := next(idx, tc)
+			runtime.ReadMemStats(&after)
+			if err != nil {
+				return err
+			}
+			if allocs := after.Mallocs - before.Mallocs; allocs > n {
+				return fmt.Errorf("made %v allocations, more than the limit of %v", allocs, n)
+			}
+			return nil
+		}
+	}
+}
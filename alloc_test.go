@@ -0,0 +1,31 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+var sink []byte
+
+func TestWithAllocLimit(t *testing.T) {
+	test := tbltest.Cases(0, 1000)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	result := test.Use(tbltest.WithAllocLimit(100)).RunResult(func(n int) {
+		for i := 0; i < n; i++ {
+			sink = make([]byte, 64)
+		}
+	})
+	if result.Passed != 1 || result.Failed != 1 {
+		t.Fatalf("expected one case to pass and one to fail, got %+v", result)
+	}
+	if err := result.Cases[1].Err; err == nil || !strings.Contains(err.Error(), "more than the limit of 100") {
+		t.Errorf("expected case 1 to go over the allocation limit, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"time"
+)
+
+// blockedDateFormat is how the date a blocked test case is disabled until is shown.
+const blockedDateFormat = "2006-01-02"
+
+// BlockedBy disables the test case until the issue, such as PROJ-123 or a link to it, is expected to be fixed.
+// Until then the test case is skipped, and the issue is logged and passed to the OnSkip hook. From the until
+// time on, the test case fails without being run, saying it is still disabled, and the run goes on with the next
+// test case; with RunT, its subtest fails. So a test case that was turned off for a while is not forgotten: the
+// issue gets fixed and BlockedBy removed, or the date is moved on.
+//
+//	test := tbltest.Cases(
+//		tbltest.BlockedBy(testcase{input: "ünïcode"}, "PROJ-123", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+//	)
+func BlockedBy(tcase TestCase, issue string, until time.Time) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.blockedBy, e.blockedUntil = issue, until
+	})
+}
+
+// filterBlocked returns the indexes in list of the test cases that are not blocked, or are blocked for longer than
+// they were meant to be.
+func (tc *Test) filterBlocked(list []int, now time.Time) []int {
+	var filtered []int
+	for _, idx := range list {
+		if idx >= 0 && idx < len(tc.cases) && tc.cases[idx].blockedBy != "" && now.Before(tc.cases[idx].blockedUntil) {
+			e := &tc.cases[idx]
+			reason := fmt.Sprintf("blocked by %v until %v", e.blockedBy, e.blockedUntil.Format(blockedDateFormat))
+			logf("Skipping test case %v (%v), %v.", idx, e.caseName(idx), reason)
+			tc.skip([]int{idx}, nil, reason)
+			continue
+		}
+		filtered = append(filtered, idx)
+	}
+	return filtered
+}
+
+// blockedError is the failure of a test case that is still blocked after the date it was blocked until.
+func blockedError(e *entry) error {
+	return fmt.Errorf("the test case is still disabled by BlockedBy(%q), which expired on %v; fix the issue and remove BlockedBy, or give it a later date",
+		e.blockedBy, e.blockedUntil.Format(blockedDateFormat))
+}
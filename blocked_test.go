@@ -0,0 +1,78 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestBlockedBy(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	test := tbltest.Cases(
+		0,
+		tbltest.BlockedBy(1, "PROJ-123", future),
+		tbltest.BlockedBy(2, "PROJ-7", past),
+		3,
+	)
+	test.InOrder = true
+	var skipped []string
+	test.OnSkip = func(idx int, name string, tc tbltest.TestCase, err error) { skipped = append(skipped, err.Error()) }
+	var ran []int
+	test.Run(func(tc int) { ran = append(ran, tc) })
+
+	if len(ran) != 2 || ran[0] != 0 || ran[1] != 3 {
+		t.Errorf("expected test cases 0 and 3 to run, got %v", ran)
+	}
+	if want := "blocked by PROJ-123 until " + future.Format("2006-01-02"); len(skipped) != 1 || skipped[0] != want {
+		t.Errorf("expected test case 1 to be skipped as %q, got %q", want, skipped)
+	}
+	if !strings.Contains(buf.String(), "Skipping test case 1 (1), blocked by PROJ-123") {
+		t.Errorf("expected the blocked test case to be logged, got %q", buf.String())
+	}
+	results := test.Results()
+	if len(results) != 3 || results[1].Status != tbltest.StatusFail || results[1].Err == nil || !strings.Contains(results[1].Err.Error(), `BlockedBy("PROJ-7"), which expired on 2020-01-02`) {
+		t.Errorf("expected test case 2 to fail as its block expired, got %+v", results)
+	}
+	if !strings.Contains(buf.String(), "Test case 2 (2) failed: the test case is still disabled") {
+		t.Errorf("expected the expired test case to be logged as failed, got %q", buf.String())
+	}
+}
+
+func TestBlockedByRunT(t *testing.T) {
+	if os.Getenv(childEnv) == "1" {
+		test := tbltest.Cases(
+			tbltest.Named(tbltest.BlockedBy(0, "PROJ-7", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)), "expired"),
+			tbltest.Named(1, "next"),
+		)
+		test.InOrder = true
+		test.RunT(t, func(t *testing.T, tc int) {})
+		return
+	}
+	out, passed := runChild(t, "TestBlockedByRunT")
+	if passed {
+		t.Errorf("expected the expired test case to fail the test, got %s", out)
+	}
+	for _, want := range []string{
+		"--- FAIL: TestBlockedByRunT/expired",
+		`BlockedBy("PROJ-7"), which expired on 2020-01-02`,
+		"--- PASS: TestBlockedByRunT/next",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected the output to contain %q, got %s", want, out)
+		}
+	}
+}
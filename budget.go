@@ -0,0 +1,28 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"strings"
+)
+
+var maxDuration = flagDuration("MaxDuration", 0, "If not zero, stop starting new test cases of a table once it has run for this long, e.g. 30s.")
+
+// maxListed is the most indexes summarizeIndexes lists.
+const maxListed = 50
+
+// summarizeIndexes returns the indexes separated by commas, leaving out those after the first maxListed.
+func summarizeIndexes(idxs []int) string {
+	var parts []string
+	for i, idx := range idxs {
+		if i == maxListed {
+			parts = append(parts, fmt.Sprintf("and %v more", len(idxs)-maxListed))
+			break
+		}
+		parts = append(parts, fmt.Sprint(idx))
+	}
+	return strings.Join(parts, ", ")
+}
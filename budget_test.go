@@ -0,0 +1,40 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestMaxDuration(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	flag.Set("tblTest.MaxDuration", "50ms")
+	defer flag.Set("tblTest.MaxDuration", "0")
+
+	var skipped int
+	test := tbltest.Generate(100, func(idx int) int { return idx })
+	test.InOrder = true
+	test.OnSkip = func(idx int, name string, tc tbltest.TestCase, err error) { skipped++ }
+	result := test.RunResult(func(tc int) { time.Sleep(20 * time.Millisecond) })
+	if result.Ran == 0 || result.Ran > 4 {
+		t.Errorf("expected the run to stop after about 3 test cases, ran %v", result.Ran)
+	}
+	if result.Skipped != 100-result.Ran || skipped != result.Skipped {
+		t.Errorf("expected the %v test cases not run to be skipped, got %v skipped and %v calls of OnSkip", 100-result.Ran, result.Skipped, skipped)
+	}
+	if !strings.Contains(buf.String(), "test cases were not run: ") || !strings.Contains(buf.String(), " more") {
+		t.Errorf("expected the test cases not run to be logged, got log: %v", buf.String())
+	}
+}
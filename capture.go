@@ -0,0 +1,67 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// CaptureOutput captures what is written to os.Stdout and os.Stderr while each test case runs. The output of a
+// test case that fails is added to its failure, and that of a test case that passes is discarded, so the output
+// of a noisy table is not interleaved across its test cases. Only writes through the os.Stdout and os.Stderr
+// variables are captured; a logger made before the run, such as the standard one of the log package, keeps
+// writing where it was set to. As the variables are shared by the whole process, tables capturing their output
+// should not be run in parallel.
+func (tc *Test) CaptureOutput() *Test {
+	return tc.Use(captureOutput)
+}
+
+// captureOutput is the Middleware of CaptureOutput.
+func captureOutput(next CaseFunc) CaseFunc {
+	return func(idx int, tcase TestCase) (err error) {
+		r, w, perr := os.Pipe()
+		if perr != nil {
+			logf("Could not capture the output of test case %v: %v", idx, perr)
+			return next(idx, tcase)
+		}
+		var buf bytes.Buffer
+		done := make(chan struct{})
+		go func() {
+			io.Copy(&buf, r)
+			r.Close()
+			close(done)
+		}()
+		stdout, stderr := os.Stdout, os.Stderr
+		os.Stdout, os.Stderr = w, w
+		defer func() {
+			os.Stdout, os.Stderr = stdout, stderr
+			w.Close()
+			<-done
+			if err != nil && buf.Len() > 0 {
+				err = outputError{err: err, label: "output", output: buf.String()}
+			}
+		}()
+		return next(idx, tcase)
+	}
+}
+
+// outputError is the failure of a test case, with the output captured while it ran, or what it logged, as told
+// by the label.
+type outputError struct {
+	err    error
+	label  string
+	output string
+}
+
+func (e outputError) Error() string {
+	output := strings.TrimRight(e.output, "\n")
+	return e.err.Error() + "\n\t" + e.label + ":\n\t\t" + strings.Replace(output, "\n", "\n\t\t", -1)
+}
+
+// Unwrap returns the failure of the test case.
+func (e outputError) Unwrap() error { return e.err }
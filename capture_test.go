@@ -0,0 +1,44 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestCaptureOutput(t *testing.T) {
+	stdout := os.Stdout
+	test := tbltest.Cases(0, 1, 2).CaptureOutput()
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	test.Run(func(tc int) bool {
+		fmt.Printf("stdout of %v\n", tc)
+		fmt.Fprintf(os.Stderr, "stderr of %v\n", tc)
+		return tc != 1
+	})
+	if os.Stdout != stdout {
+		t.Errorf("expected os.Stdout to be restored")
+	}
+	for _, cr := range test.Results() {
+		if cr.Index != 1 {
+			if cr.Err != nil {
+				t.Errorf("test case %v: unexpected error %v", cr.Index, cr.Err)
+			}
+			continue
+		}
+		want := "test function returned false\n\toutput:\n\t\tstdout of 1\n\t\tstderr of 1"
+		if cr.Err == nil || cr.Err.Error() != want {
+			t.Errorf("test case 1: expected the error %q, got %v", want, cr.Err)
+		}
+	}
+	if out := fmt.Sprint(test.Results()); strings.Contains(out, "of 0") {
+		t.Errorf("expected the output of passing test cases to be discarded, got %v", out)
+	}
+}
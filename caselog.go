@@ -0,0 +1,65 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var flushLogs = flagBool("FlushLogs", false, "Log what test cases log with Test.Logf as they log it, instead of only adding it to the failures of the test cases that fail.")
+
+// caseLogs holds what the test case that is running logged with Logf.
+type caseLogs struct {
+	mu sync.Mutex
+	// running is set while a test case runs, which is named by idx and name.
+	running bool
+	idx     int
+	name    string
+	buf     bytes.Buffer
+}
+
+// start starts holding the logs of the test case.
+func (l *caseLogs) start(idx int, name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.running, l.idx, l.name = true, idx, name
+	l.buf.Reset()
+}
+
+// stop returns what the test case logged.
+func (l *caseLogs) stop() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.running = false
+	return l.buf.String()
+}
+
+// Logf logs a message for the test case that is running, like t.Logf: the messages of a test case are only added
+// to its failure if it fails, and are discarded if it passes. With the tblTest.FlushLogs option, or if no test
+// case of the table is running, the message is logged right away instead.
+//
+//	test.Run(func(tc testcase) bool {
+//		got := Parse(tc.input)
+//		test.Logf("parsed %q as %#v", tc.input, got)
+//		return reflect.DeepEqual(got, tc.want)
+//	})
+func (tc *Test) Logf(format string, args ...interface{}) {
+	msg := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	l := &tc.logs
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case !l.running:
+		logf("%v", msg)
+	case *flushLogs:
+		logf("test case %v (%v): %v", l.idx, l.name, msg)
+	default:
+		l.buf.WriteString(msg)
+		l.buf.WriteByte('\n')
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestLogf(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	test := tbltest.Cases(0, 1)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	fn := func(tc int) bool {
+		test.Logf("checking %v", tc)
+		return tc != 1
+	}
+	test.Run(fn)
+	for _, cr := range test.Results() {
+		switch {
+		case cr.Index == 0 && cr.Err != nil:
+			t.Errorf("test case 0: unexpected error %v", cr.Err)
+		case cr.Index == 1 && (cr.Err == nil || !strings.HasSuffix(cr.Err.Error(), "\n\tlog:\n\t\tchecking 1")):
+			t.Errorf("test case 1: expected the error to hold what it logged, got %v", cr.Err)
+		}
+	}
+	if strings.Contains(buf.String(), "checking 0") {
+		t.Errorf("expected what the passing test case logged to be discarded, got %q", buf.String())
+	}
+
+	buf.Reset()
+	flag.Set("tblTest.FlushLogs", "true")
+	defer flag.Set("tblTest.FlushLogs", "false")
+	test.Run(fn)
+	for _, want := range []string{"test case 0 (0): checking 0", "test case 1 (1): checking 1"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected the log to contain %q, got %q", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RunChunks runs the table in chunks of up to size test cases, calling function once with each chunk, for test
+// functions that can share the cost of their setup across many test cases, such as running a chunk in one
+// database transaction. function must be of one of the forms:
+//
+//	func(chunk []$testcase) bool
+//	func(chunk []$testcase) error
+//
+// The chunks are formed from the test cases that are going to run, in the order they run, and function is
+// called as the first test case of each chunk is reached. All the test cases of a chunk pass or fail together,
+// with the failures naming the chunk. RunChunks returns the number of test cases run.
+//
+//	test.RunChunks(100, func(chunk []testcase) error {
+//		tx := db.Begin()
+//		defer tx.Rollback()
+//		...
+//	})
+func (tc *Test) RunChunks(size int, function interface{}) int {
+	if size < 1 {
+		panicf("RunChunks should be given a chunk size of at least 1, was given %v", size)
+	}
+	if tc.vType == nil {
+		return tc.runWith(tc.newCheckRunner(nil)).Ran
+	}
+	fn := reflect.ValueOf(function)
+	sliceType := reflect.SliceOf(tc.vType)
+	if fn.Kind() != reflect.Func || fn.Type().NumIn() != 1 || fn.Type().In(0) != sliceType || fn.Type().NumOut() != 1 ||
+		(fn.Type().Out(0).Kind() != reflect.Bool && fn.Type().Out(0) != errorType) {
+		panicf("RunChunks should be given a function of the form func(chunk %v) bool or func(chunk %v) error, was given %T", sliceType, sliceType, function)
+	}
+	// chunkOf is the chunk of each test case that is going to run, and errs the outcome of each chunk that ran.
+	var chunks [][]int
+	chunkOf := make(map[int]int)
+	errs := make(map[int]error)
+	var rn *runner
+	rn = tc.newCheckRunner(func(idx int, tcase TestCase) error {
+		c := chunkOf[idx]
+		err, ok := errs[c]
+		if !ok {
+			err = tc.runChunk(rn, fn, chunks[c], idx, tcase)
+			if err != nil {
+				err = fmt.Errorf("chunk %v, of test cases %v, failed: %v", c, summarizeIndexes(chunks[c]), err)
+			}
+			errs[c] = err
+		}
+		return err
+	})
+	rn.plan = func(list []int) {
+		for i := 0; i < len(list); i += size {
+			end := i + size
+			if end > len(list) {
+				end = len(list)
+			}
+			for _, idx := range list[i:end] {
+				chunkOf[idx] = len(chunks)
+			}
+			chunks = append(chunks, list[i:end])
+		}
+	}
+	return tc.runWith(rn).Ran
+}
+
+// runChunk calls fn with the test cases of chunk. The test case idx, that the chunk was reached with, is tcase.
+func (tc *Test) runChunk(rn *runner, fn reflect.Value, chunk []int, idx int, tcase TestCase) error {
+	values := reflect.MakeSlice(fn.Type().In(0), len(chunk), len(chunk))
+	for i, j := range chunk {
+		v := tcase
+		if j != idx {
+			var err error
+			if v, err = rn.prepare(tc.cases[j].get().Interface()); err != nil {
+				return fmt.Errorf("test case %v: %v", j, err)
+			}
+		}
+		values.Index(i).Set(reflect.ValueOf(v))
+	}
+	out := fn.Call([]reflect.Value{values})[0]
+	if out.Kind() == reflect.Bool {
+		if !out.Bool() {
+			return errReturnedFalse
+		}
+		return nil
+	}
+	err, _ := out.Interface().(error)
+	return err
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRunChunks(t *testing.T) {
+	test := tbltest.Cases(0, 1, 2, 3, 4, 5, 6)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	var chunks [][]int
+	n := test.RunChunks(3, func(chunk []int) error {
+		chunks = append(chunks, chunk)
+		if chunk[0] == 3 {
+			return errors.New("bad chunk")
+		}
+		return nil
+	})
+	if n != 7 {
+		t.Errorf("expected 7 test cases to run, got %v", n)
+	}
+	if want := [][]int{{0, 1, 2}, {3, 4, 5}, {6}}; !reflect.DeepEqual(chunks, want) {
+		t.Errorf("expected the chunks %v, got %v", want, chunks)
+	}
+	for _, cr := range test.Results() {
+		if failed := cr.Err != nil; failed != (cr.Index >= 3 && cr.Index <= 5) {
+			t.Errorf("test case %v: unexpected error %v", cr.Index, cr.Err)
+		} else if failed && !strings.Contains(cr.Err.Error(), "chunk 1, of test cases 3, 4, 5, failed: bad chunk") {
+			t.Errorf("test case %v: expected the error to name the chunk, got %v", cr.Index, cr.Err)
+		}
+	}
+}
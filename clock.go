@@ -0,0 +1,129 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time to the code under test, so a test case can run it at a time of its choosing.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After sends the time on the returned channel once d has passed.
+	After(d time.Duration) <-chan time.Time
+	// Sleep returns once d has passed.
+	Sleep(d time.Duration)
+}
+
+var clockType = reflect.TypeOf((*Clock)(nil)).Elem()
+
+// realClock is the Clock of the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+// FakeClock is a Clock whose time only moves when it is told to, so the code under test runs at a fixed time,
+// and its timers fire without waiting for them.
+type FakeClock struct {
+	mu     sync.Mutex
+	start  time.Time
+	now    time.Time
+	timers []fakeTimer
+}
+
+// fakeTimer is a channel waiting on the time of a FakeClock.
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFakeClock returns a FakeClock stopped at t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{start: t, now: t}
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that is sent the time of the clock once it has been advanced by d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Sleep advances the clock by d, as if that long had passed, rather than waiting.
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// Advance moves the clock forward by d, firing the channels of After that are due, in the order they are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	sort.Sort(byDue(c.timers))
+	i := 0
+	for ; i < len(c.timers) && !c.timers[i].at.After(c.now); i++ {
+		c.timers[i].ch <- c.now
+	}
+	c.timers = c.timers[i:]
+}
+
+// byDue sorts timers by when they are due.
+type byDue []fakeTimer
+
+func (b byDue) Len() int           { return len(b) }
+func (b byDue) Less(i, j int) bool { return b[i].at.Before(b[j].at) }
+func (b byDue) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// WithClock sets the Clock given to a test function of the form
+//
+//	func(clock tbltest.Clock, tc $testcase)
+//	func(clock tbltest.Clock, tc $testcase) bool
+//
+// which is the Clock of the time package by default. If clock is a *FakeClock, each test case is given a
+// FakeClock of its own, stopped at the time clock was created at, so the test cases all run at the same time and
+// do not see each other advance it.
+//
+//	test.WithClock(tbltest.NewFakeClock(time.Date(2020, 2, 29, 23, 59, 0, 0, time.UTC)))
+//	test.Run(func(clock tbltest.Clock, tc testcase) bool {
+//		cache := NewCache(clock)
+//		cache.Put(tc.key, tc.value, time.Minute)
+//		clock.Sleep(tc.wait)
+//		_, ok := cache.Get(tc.key)
+//		return ok == tc.wantHit
+//	})
+func (tc *Test) WithClock(clock Clock) *Test {
+	tc.clock = clock
+	return tc
+}
+
+// caseClock returns the Clock to give to the next test case.
+func (tc *Test) caseClock() Clock {
+	switch c := tc.clock.(type) {
+	case nil:
+		return realClock{}
+	case *FakeClock:
+		return NewFakeClock(c.start)
+	}
+	return tc.clock
+}
@@ -0,0 +1,45 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestWithClock(t *testing.T) {
+	start := time.Date(2020, 2, 29, 23, 59, 0, 0, time.UTC)
+	test := tbltest.Cases(time.Minute, time.Hour, 30*time.Second).WithClock(tbltest.NewFakeClock(start))
+	test.ContinueOnFailure = true
+	n := test.Run(func(clock tbltest.Clock, wait time.Duration) bool {
+		if !clock.Now().Equal(start) {
+			t.Errorf("expected each test case to start at %v, got %v", start, clock.Now())
+		}
+		fired := clock.After(time.Minute)
+		clock.Sleep(wait)
+		select {
+		case <-fired:
+			return wait >= time.Minute
+		default:
+			return wait < time.Minute
+		}
+	})
+	if n != 3 {
+		t.Errorf("expected 3 test cases to run, got %v", n)
+	}
+	for _, cr := range test.Results() {
+		if cr.Err != nil {
+			t.Errorf("test case %v: unexpected error %v", cr.Index, cr.Err)
+		}
+	}
+
+	var now time.Time
+	tbltest.Cases(0).Run(func(clock tbltest.Clock, tc int) { now = clock.Now() })
+	if time.Since(now) > time.Minute {
+		t.Errorf("expected the real clock by default, got %v", now)
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+// Command tbl lists and runs the test cases of the tables, built with github.com/gdey/tbltest, in a package's
+// tests. It drives go test with the tblTest flags, so scripts and CI jobs can discover cases and run selections
+// or shards of them without remembering the flags.
+//
+// Usage:
+//
+//	tbl list [-run regexp] [-tags tags] [packages]
+//	tbl tables [-run regexp] [packages]
+//	tbl run -test TestName -cases 1,3,5 [packages]
+//	tbl run -table name [-cases 1,3,5] [packages]
+//	tbl shard -shard index/total [-run regexp] [packages]
+//
+// list prints a line for each test case, with the test function, the index, name, tags and definition site of
+// the test case. Only the tests declared in the test files that import tbltest are run to list them. tables
+// prints a line for each table registered with tbltest.Register, with its name and the test functions that run
+// it; run -table runs only that table, from those test functions. The packages must all use tbltest, as the
+// tblTest flags are passed to each of their test binaries.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// importPath is the import path of tbltest, which the test files with tables import.
+const importPath = "github.com/gdey/tbltest"
+
+// listPrefix starts the lines of the tblTest.List listing when tblTest.ListFormat is json.
+const listPrefix = "tbltest.case "
+
+// tblCase is a test case, as listed by the tblTest.List flag.
+type tblCase struct {
+	Test   string   `json:"test"`
+	Table  string   `json:"table"`
+	Index  int      `json:"index"`
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags"`
+	Source string   `json:"source"`
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	var err error
+	switch os.Args[1] {
+	case "list":
+		err = list(os.Args[2:])
+	case "tables":
+		err = tables(os.Args[2:])
+	case "run":
+		err = run(os.Args[2:])
+	case "shard":
+		err = shard(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tbl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage:\n"+
+		"\ttbl list [-run regexp] [-tags tags] [packages]\n"+
+		"\ttbl tables [-run regexp] [packages]\n"+
+		"\ttbl run -test TestName -cases 1,3,5 [packages]\n"+
+		"\ttbl run -table name [-cases 1,3,5] [packages]\n"+
+		"\ttbl shard -shard index/total [-run regexp] [packages]\n")
+	os.Exit(2)
+}
+
+func list(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	pattern := fs.String("run", ".", "Only list the tables of the tests matching the regular expression.")
+	tags := fs.String("tags", "", "Only list the test cases selected by the comma separated tags.")
+	fs.Parse(args)
+
+	var tblArgs []string
+	if *tags != "" {
+		tblArgs = append(tblArgs, "-tblTest.Tags="+*tags)
+	}
+	cases, err := listCases(fs.Args(), *pattern, tblArgs...)
+	// Tests may check what their tables ran, and fail while listing; so the listing is printed either way.
+	for _, c := range cases {
+		tags := "-"
+		if len(c.Tags) > 0 {
+			tags = strings.Join(c.Tags, ",")
+		}
+		fmt.Printf("%v\t%v\t%v\t%v\t%v\n", c.Test, c.Index, c.Name, tags, c.Source)
+	}
+	if err != nil {
+		return fmt.Errorf("list: %v", err)
+	}
+	return nil
+}
+
+func tables(args []string) error {
+	fs := flag.NewFlagSet("tables", flag.ExitOnError)
+	pattern := fs.String("run", ".", "Only list the tables of the tests matching the regular expression.")
+	fs.Parse(args)
+
+	cases, err := listCases(fs.Args(), *pattern)
+	for _, name := range tableNames(cases) {
+		fmt.Printf("%v\t%v\n", name, strings.Join(testsOf(cases, name), ","))
+	}
+	if err != nil {
+		return fmt.Errorf("tables: %v", err)
+	}
+	return nil
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	test := fs.String("test", "", "The name of the test function to run.")
+	table := fs.String("table", "", "The name of the registered table to run, instead of a test function.")
+	cases := fs.String("cases", "", "Comma separated indexes of the test cases to run, in the order to run them.")
+	fs.Parse(args)
+	if *test == "" && *table == "" {
+		return fmt.Errorf("run: either the -test or the -table flag is required")
+	}
+	var tblArgs []string
+	pattern := "^" + *test + "$"
+	if *table != "" {
+		tblArgs = append(tblArgs, "-tblTest.Table="+*table)
+		if *test == "" {
+			listed, err := listCases(fs.Args(), ".", tblArgs...)
+			if err != nil {
+				return fmt.Errorf("run: %v", err)
+			}
+			tests := testsOf(listed, *table)
+			if len(tests) == 0 {
+				return fmt.Errorf("run: no test runs a table registered as %q", *table)
+			}
+			pattern = "^(" + strings.Join(tests, "|") + ")$"
+		}
+	}
+	if *cases != "" {
+		tblArgs = append(tblArgs, "-tblTest.RunOrder="+*cases)
+	}
+	return goTest(fs.Args(), pattern, tblArgs...).Run()
+}
+
+func shard(args []string) error {
+	fs := flag.NewFlagSet("shard", flag.ExitOnError)
+	pattern := fs.String("run", ".", "Only run the tests matching the regular expression.")
+	s := fs.String("shard", "", "The shard of the test cases to run, given as index/total.")
+	fs.Parse(args)
+	if *s == "" {
+		return fmt.Errorf("shard: the -shard flag is required")
+	}
+	return goTest(fs.Args(), *pattern, "-tblTest.Shard="+*s).Run()
+}
+
+// goTest returns the go test command for the packages and tests matching pattern, passing the tblArgs to the
+// test binaries.
+func goTest(pkgs []string, pattern string, tblArgs ...string) *exec.Cmd {
+	args := []string{"test", "-v", "-run", pattern}
+	args = append(args, pkgs...)
+	args = append(args, "-args")
+	args = append(args, tblArgs...)
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// listCases lists the test cases of the tables run by the tests matching pattern, passing the tblArgs to the test
+// binaries along with the flags to list them. The test cases listed are returned even if go test fails.
+func listCases(pkgs []string, pattern string, tblArgs ...string) ([]tblCase, error) {
+	tests, err := tableTests(pkgs, pattern)
+	if err != nil || tests == "" {
+		return nil, err
+	}
+	cmd := goTest(pkgs, tests, append([]string{"-tblTest.List", "-tblTest.ListFormat=json"}, tblArgs...)...)
+	cmd.Stdout = nil
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	cases := parseList(out)
+	if err := cmd.Wait(); err != nil {
+		return cases, fmt.Errorf("go test: %v", err)
+	}
+	return cases, nil
+}
+
+// tableNames returns the names of the registered tables the test cases belong to, sorted.
+func tableNames(cases []tblCase) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, c := range cases {
+		if c.Table != "" && !seen[c.Table] {
+			seen[c.Table] = true
+			names = append(names, c.Table)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// testsOf returns the test functions that run the table registered as name, sorted.
+func testsOf(cases []tblCase, name string) []string {
+	seen := make(map[string]bool)
+	var tests []string
+	for _, c := range cases {
+		if c.Table == name && !seen[c.Test] {
+			seen[c.Test] = true
+			tests = append(tests, c.Test)
+		}
+	}
+	sort.Strings(tests)
+	return tests
+}
+
+// parseList returns the test cases listed in the output of a run with the tblTest.List flag, in the json
+// format. Lines that are not part of the listing are ignored.
+func parseList(r io.Reader) (cases []tblCase) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, listPrefix) {
+			continue
+		}
+		var c tblCase
+		if err := json.Unmarshal([]byte(line[len(listPrefix):]), &c); err != nil {
+			continue
+		}
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+// tableTests returns a -run pattern that selects the tests matching pattern that are declared in the test files
+// of the packages that import tbltest, so listing does not run the other tests. It returns the empty string if
+// there are none.
+func tableTests(pkgs []string, pattern string) (string, error) {
+	top := pattern
+	var sub string
+	if i := strings.Index(pattern, "/"); i >= 0 {
+		top, sub = pattern[:i], pattern[i:]
+	}
+	re, err := regexp.Compile(top)
+	if err != nil {
+		return "", fmt.Errorf("invalid -run pattern: %v", err)
+	}
+	args := append([]string{"list", "-f", `{{range .TestGoFiles}}{{$.Dir}}{{"\t"}}{{.}}{{"\n"}}{{end}}{{range .XTestGoFiles}}{{$.Dir}}{{"\t"}}{{.}}{{"\n"}}{{end}}`}, pkgs...)
+	cmd := exec.Command("go", args...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("go list: %v", err)
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		tests, err := testFuncs(filepath.Join(fields[0], fields[1]), nil)
+		if err != nil {
+			return "", err
+		}
+		for _, name := range tests {
+			if re.MatchString(name) && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	sort.Strings(names)
+	return "^(" + strings.Join(names, "|") + ")$" + sub, nil
+}
+
+// testFuncs returns the names of the test functions declared in the file, if it imports tbltest. src, if not nil,
+// is the source of the file, as for parser.ParseFile.
+func testFuncs(filename string, src interface{}) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	imported := false
+	for _, imp := range f.Imports {
+		if imp.Path.Value == `"`+importPath+`"` {
+			imported = true
+		}
+	}
+	if !imported {
+		return nil, nil
+	}
+	var names []string
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !isTest(fn.Name.Name) || fn.Type.Params.NumFields() != 1 {
+			continue
+		}
+		names = append(names, fn.Name.Name)
+	}
+	return names, nil
+}
+
+// isTest reports whether name is the name of a test function, as go test decides: Test, followed by nothing or by
+// a character that is not a lower case letter. TestMain is not a test.
+func isTest(name string) bool {
+	if !strings.HasPrefix(name, "Test") || name == "TestMain" {
+		return false
+	}
+	if len(name) == len("Test") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len("Test"):])
+	return !unicode.IsLower(r)
+}
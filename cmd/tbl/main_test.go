@@ -0,0 +1,116 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	out := "=== RUN   TestFoo\n" +
+		`tbltest.case {"test":"TestFoo","index":0,"name":"empty","source":"foo_test.go:12"}` + "\n" +
+		`tbltest.case {"test":"TestFoo","index":1,"name":"1","tags":["slow","network"],"source":"foo_test.go:12"}` + "\n" +
+		"TestFoo\t2\tprinted by a test\t-\tfoo_test.go:20\n" +
+		"--- PASS: TestFoo (0.00s)\n" +
+		"tbltest.case {not json\n" +
+		"PASS\n"
+	want := []tblCase{
+		{Test: "TestFoo", Index: 0, Name: "empty", Source: "foo_test.go:12"},
+		{Test: "TestFoo", Index: 1, Name: "1", Tags: []string{"slow", "network"}, Source: "foo_test.go:12"},
+	}
+	if got := parseList(strings.NewReader(out)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseList returned %v, expected %v", got, want)
+	}
+}
+
+func TestTestFuncs(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		want []string
+	}{
+		{
+			src: `package foo_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestMain(m *testing.M) {}
+func TestParse(t *testing.T) {}
+func Testing(t *testing.T)   {}
+func TestÄ(t *testing.T)     {}
+func helper(t *testing.T)    {}
+func (s suite) TestX(t *testing.T) {}
+var cases = tbltest.Cases(1)
+`,
+			want: []string{"TestParse", "TestÄ"},
+		},
+		{
+			src: `package foo
+
+import "testing"
+
+func TestOther(t *testing.T) {}
+`,
+		},
+	} {
+		got, err := testFuncs("foo_test.go", tt.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("testFuncs returned %v, expected %v", got, tt.want)
+		}
+	}
+}
+
+func TestTableTests(t *testing.T) {
+	for _, tt := range []struct {
+		pkg, pattern, want string
+	}{
+		// The tests of tbltest itself import it, from their external test package.
+		{"../..", "^TestList", "^(TestList|TestListJSON)$"},
+		{"../..", "^TestList$/sub", "^(TestList)$/sub"},
+		// The tests of the tbl command do not.
+		{".", ".", ""},
+	} {
+		got, err := tableTests([]string{tt.pkg}, tt.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("tableTests(%v, %q) returned %q, expected %q", tt.pkg, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestRegisteredTables(t *testing.T) {
+	pkgs := []string{"./testdata/registered"}
+	cases, err := listCases(pkgs, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tableNames(cases), []string{"format", "parse"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the registered tables %v, got %v", want, got)
+	}
+	if got, want := testsOf(cases, "parse"), []string{"TestParse"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the table to be run by %v, got %v", want, got)
+	}
+
+	cases, err = listCases(pkgs, ".", "-tblTest.Table=parse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 || cases[0].Table != "parse" || cases[1].Table != "parse" {
+		t.Errorf("expected only the cases of the parse table to be listed, got %v", cases)
+	}
+	if err := run(append([]string{"-table", "parse", "-cases", "1"}, pkgs...)); err != nil {
+		t.Errorf("expected the table to run, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+// Package registered has registered tables, for the tests of the tbl command.
+package registered_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+var (
+	parse  = tbltest.Register("parse", tbltest.Cases(1, 2))
+	format = tbltest.Register("format", tbltest.Cases(3))
+)
+
+func TestParse(t *testing.T) {
+	parse.Run(func(tc int) bool { return tc > 0 })
+}
+
+func TestFormat(t *testing.T) {
+	format.Run(func(tc int) bool { return tc > 0 })
+}
+
+func TestUnregistered(t *testing.T) {
+	tbltest.Cases(4).Run(func(tc int) bool { return tc > 0 })
+}
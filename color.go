@@ -0,0 +1,45 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "os"
+
+const (
+	colorNever  = "never"
+	colorAuto   = "auto"
+	colorAlways = "always"
+)
+
+var colorMode = flagString("Color", colorNever, "Color the failures and diffs in the output: never, auto (if stderr is a terminal and NO_COLOR is not set) or always.")
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiBold  = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+// useColor returns whether the output should be colored, following the tblTest.Color option.
+func useColor() bool {
+	switch *colorMode {
+	case colorAlways:
+		return true
+	case colorAuto:
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			return false
+		}
+		fi, err := os.Stderr.Stat()
+		return err == nil && fi.Mode()&os.ModeCharDevice != 0
+	}
+	return false
+}
+
+// colorize returns s in the ANSI color given by code, if the output is colored.
+func colorize(code string, s string) string {
+	if !useColor() {
+		return s
+	}
+	return code + s + ansiReset
+}
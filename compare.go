@@ -0,0 +1,258 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var comparers struct {
+	sync.RWMutex
+	byType map[reflect.Type]reflect.Value
+}
+
+// RegisterComparer registers a function to decide whether two values of a type are equal, for Equal and Diff and
+// the features built on them. It must be of the form:
+//
+//	func(a, b $T) bool
+//
+// The comparer is used wherever a value of the type is found, including inside structs, slices and maps, except
+// in unexported struct fields, as their values can not be passed to it; those are compared as by Equal without
+// a comparer. This allows for domain specific equality, such as times that are within a millisecond of each
+// other:
+//
+//	tbltest.RegisterComparer(func(a, b time.Time) bool {
+//		d := a.Sub(b)
+//		return -time.Millisecond < d && d < time.Millisecond
+//	})
+//
+// Registering a comparer for a type replaces the one registered before. The returned function undoes the
+// registration, restoring the comparer that was replaced, if any; tests that register a comparer should call it
+// when they are done, such as with t.Cleanup.
+func RegisterComparer(comparer interface{}) (unregister func()) {
+	fn := reflect.ValueOf(comparer)
+	if fn.Kind() != reflect.Func {
+		panicf("RegisterComparer was not provided a function.")
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 2 || fnType.In(0) != fnType.In(1) || fnType.NumOut() != 1 || fnType.Out(0) != reflect.TypeOf(true) {
+		panicf("Comparer should be of the form func(a, b $T) bool, was given %v", fnType)
+	}
+	typ := fnType.In(0)
+	comparers.Lock()
+	defer comparers.Unlock()
+	if comparers.byType == nil {
+		comparers.byType = make(map[reflect.Type]reflect.Value)
+	}
+	prev, replaced := comparers.byType[typ]
+	comparers.byType[typ] = fn
+	return func() {
+		comparers.Lock()
+		defer comparers.Unlock()
+		if replaced {
+			comparers.byType[typ] = prev
+		} else {
+			delete(comparers.byType, typ)
+		}
+	}
+}
+
+func comparerFor(t reflect.Type) (reflect.Value, bool) {
+	comparers.RLock()
+	defer comparers.RUnlock()
+	fn, ok := comparers.byType[t]
+	return fn, ok
+}
+
+// Equal reports whether got and want are deeply equal, like reflect.DeepEqual, except that values of the types
+// with a registered comparer are compared with it.
+func Equal(got, want interface{}) bool {
+	d := differ{limit: 1}
+	d.diff("", reflect.ValueOf(got), reflect.ValueOf(want))
+	return len(d.diffs) == 0
+}
+
+// Diff returns a description of the differences between got and want, a line for each, or the empty string if
+// they are Equal. Each line gives the path to the value that differs, such as:
+//
+//	.Items[2].Name: got "b", want "c"
+func Diff(got, want interface{}) string {
+	d := differ{}
+	d.diff("", reflect.ValueOf(got), reflect.ValueOf(want))
+	return strings.Join(d.diffs, "\n")
+}
+
+// differ walks two values, collecting their differences.
+type differ struct {
+	diffs []string
+	// limit, if not zero, is the number of differences to stop at.
+	limit int
+	// visited are the pointers being compared, to stop at cycles.
+	visited map[[2]uintptr]bool
+}
+
+func (d *differ) done() bool {
+	return d.limit > 0 && len(d.diffs) >= d.limit
+}
+
+func (d *differ) report(path string, got, want interface{}) {
+	if path == "" {
+		path = "value"
+	}
+	d.diffs = append(d.diffs, fmt.Sprintf("%v: got %v, want %v", path, got, want))
+}
+
+func (d *differ) diff(path string, got, want reflect.Value) {
+	if d.done() {
+		return
+	}
+	if !got.IsValid() || !want.IsValid() {
+		if got.IsValid() != want.IsValid() {
+			d.report(path, describe(got), describe(want))
+		}
+		return
+	}
+	if got.Type() != want.Type() {
+		d.report(path, fmt.Sprintf("%v of type %v", describe(got), got.Type()), fmt.Sprintf("%v of type %v", describe(want), want.Type()))
+		return
+	}
+	if fn, ok := comparerFor(got.Type()); ok && got.CanInterface() && want.CanInterface() {
+		if !fn.Call([]reflect.Value{got, want})[0].Bool() {
+			d.report(path, describe(got), describe(want))
+		}
+		return
+	}
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				d.report(path, describe(got), describe(want))
+			}
+			return
+		}
+		if got.Kind() == reflect.Ptr {
+			if got.Pointer() == want.Pointer() {
+				return
+			}
+			key := [2]uintptr{got.Pointer(), want.Pointer()}
+			if d.visited[key] {
+				return
+			}
+			if d.visited == nil {
+				d.visited = make(map[[2]uintptr]bool)
+			}
+			d.visited[key] = true
+		}
+		d.diff(path, got.Elem(), want.Elem())
+	case reflect.Struct:
+		for i := 0; i < got.NumField(); i++ {
+			d.diff(path+"."+got.Type().Field(i).Name, got.Field(i), want.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if got.Kind() == reflect.Slice && got.IsNil() != want.IsNil() {
+			d.report(path, describe(got), describe(want))
+			return
+		}
+		if got.Len() != want.Len() {
+			d.report(path+".len()", got.Len(), want.Len())
+			return
+		}
+		for i := 0; i < got.Len(); i++ {
+			d.diff(fmt.Sprintf("%v[%v]", path, i), got.Index(i), want.Index(i))
+		}
+	case reflect.Map:
+		if got.IsNil() != want.IsNil() {
+			d.report(path, describe(got), describe(want))
+			return
+		}
+		for _, k := range sortedKeys(got, want) {
+			kpath := fmt.Sprintf("%v[%#v]", path, k)
+			gv, wv := got.MapIndex(k), want.MapIndex(k)
+			switch {
+			case !gv.IsValid():
+				d.report(kpath, "no value", describe(wv))
+			case !wv.IsValid():
+				d.report(kpath, describe(gv), "no value")
+			default:
+				d.diff(kpath, gv, wv)
+			}
+			if d.done() {
+				return
+			}
+		}
+	case reflect.Func:
+		if !got.IsNil() || !want.IsNil() {
+			d.report(path, "a func", "a func; funcs are only equal if they are nil")
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if got.Pointer() != want.Pointer() {
+			d.report(path, describe(got), describe(want))
+		}
+	case reflect.Bool:
+		if got.Bool() != want.Bool() {
+			d.report(path, got.Bool(), want.Bool())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if got.Int() != want.Int() {
+			d.report(path, got.Int(), want.Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if got.Uint() != want.Uint() {
+			d.report(path, got.Uint(), want.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		if got.Float() != want.Float() {
+			d.report(path, got.Float(), want.Float())
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if got.Complex() != want.Complex() {
+			d.report(path, got.Complex(), want.Complex())
+		}
+	case reflect.String:
+		if got.String() != want.String() {
+			d.report(path, fmt.Sprintf("%q", got.String()), fmt.Sprintf("%q", want.String()))
+		}
+	}
+}
+
+// describe returns a short description of v, for a difference.
+func describe(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// sortedKeys returns the keys of both maps, sorted by their formatted value so the differences are listed in a
+// stable order.
+func sortedKeys(a, b reflect.Value) []reflect.Value {
+	keys := a.MapKeys()
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sort.Sort(byFormatted(keys))
+	return keys
+}
+
+type byFormatted []reflect.Value
+
+func (b byFormatted) Len() int           { return len(b) }
+func (b byFormatted) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byFormatted) Less(i, j int) bool { return fmt.Sprint(b[i]) < fmt.Sprint(b[j]) }
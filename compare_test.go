@@ -0,0 +1,82 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+type measurement struct {
+	At     time.Time
+	Values []float64
+	Labels map[string]string
+	note   string
+}
+
+func TestEqualAndDiff(t *testing.T) {
+	at := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := measurement{At: at, Values: []float64{1, 2}, Labels: map[string]string{"a": "x", "b": "y"}}
+	b := measurement{At: at, Values: []float64{1, 2}, Labels: map[string]string{"a": "x", "b": "y"}}
+	if !tbltest.Equal(a, b) || tbltest.Diff(a, b) != "" {
+		t.Errorf("expected equal values, got diff: %v", tbltest.Diff(a, b))
+	}
+
+	b.Values[1] = 3
+	b.Labels["b"] = "z"
+	b.note = "x"
+	want := ".Values[1]: got 2, want 3\n" +
+		`.Labels["b"]: got "y", want "z"` + "\n" +
+		`.note: got "", want "x"`
+	if tbltest.Equal(a, b) {
+		t.Errorf("expected different values to not be Equal")
+	}
+	if diff := tbltest.Diff(a, b); diff != want {
+		t.Errorf("unexpected diff:\n%v\nwanted:\n%v", diff, want)
+	}
+}
+
+// The comparers are registered for types of their own, so they do not change how other tests compare floats and
+// times.
+type (
+	approxTime  struct{ time.Time }
+	approxFloat float64
+)
+
+func TestRegisterComparer(t *testing.T) {
+	type sample struct {
+		At     approxTime
+		Values []approxFloat
+	}
+	at := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+	// Added at run time, 0.1 + 0.2 is not quite 0.3.
+	tenth := approxFloat(0.1)
+	a := sample{At: approxTime{at}, Values: []approxFloat{tenth + 0.2}}
+	b := sample{At: approxTime{at.Add(time.Microsecond)}, Values: []approxFloat{0.3}}
+	if tbltest.Equal(a, b) {
+		t.Fatalf("expected the values to differ without comparers")
+	}
+	unregisterTime := tbltest.RegisterComparer(func(a, b approxTime) bool {
+		d := a.Sub(b.Time)
+		return -time.Millisecond < d && d < time.Millisecond
+	})
+	defer unregisterTime()
+	unregisterFloat := tbltest.RegisterComparer(func(a, b approxFloat) bool { return math.Abs(float64(a-b)) < 1e-9 })
+	if diff := tbltest.Diff(a, b); diff != "" {
+		t.Errorf("expected the comparers to be used, got diff: %v", diff)
+	}
+	type hidden struct{ value approxFloat }
+	if tbltest.Equal(hidden{tenth + 0.2}, hidden{0.3}) {
+		t.Errorf("expected the comparer not to be used for an unexported field")
+	}
+	unregisterFloat()
+	if diff := tbltest.Diff(a, b); !strings.HasPrefix(diff, ".Values[0]: ") || strings.Contains(diff, ".At") {
+		t.Errorf("expected only the floats to differ once their comparer is unregistered, got diff: %v", diff)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestConcurrentAdd(t *testing.T) {
+	test := tbltest.Cases()
+	other := tbltest.Cases(-1, -2)
+	// The random test cases are numbered from 1000, so they differ from the others.
+	next := int64(999)
+	gen := func(r *rand.Rand) int { return int(atomic.AddInt64(&next, 1)) }
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				test.Add(w*100 + i)
+				if n := test.Len(); w == 2 && n > 0 {
+					// Reading the table while it grows is safe too.
+					test.Case(n - 1)
+					test.Name(n - 1)
+				}
+			}
+			switch w {
+			case 0:
+				test.Merge(other)
+			case 1:
+				test.AddRandom(10, gen)
+			}
+		}(w)
+	}
+	wg.Wait()
+	seen := make(map[int]bool)
+	if n := test.Run(func(tc int) { seen[tc] = true }); n != 812 {
+		t.Errorf("expected 812 test cases to run, got %v", n)
+	}
+	if len(seen) != 812 {
+		t.Errorf("expected 812 different test cases, got %v", len(seen))
+	}
+}
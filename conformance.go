@@ -0,0 +1,101 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"text/tabwriter"
+)
+
+// RunAgainst runs every test case against each of the implementations, to check that alternate backends
+// behave the same. impls must be a map from the name of each implementation to the implementation, usually
+// an interface they all satisfy, and the function must be of the form:
+//
+//	func(t *testing.T, impl $impl, tc $testcase)
+//
+// For example:
+//
+//	test.RunAgainst(t, map[string]Store{"memory": newMemStore(), "bolt": newBoltStore(dir)}, func(t *testing.T, s Store, tc testcase) {
+//		...
+//	})
+//
+// Each implementation is a subtest of t, in the order of their names, and its test cases are subtests of it as
+// with RunT, as in TestStore/bolt/3. If any test case failed, a matrix of the test cases against the
+// implementations is logged, showing which failed for which implementation. The results of each
+// implementation are returned by name.
+func (tc *Test) RunAgainst(t *testing.T, impls interface{}, function interface{}) map[string]*Result {
+	m := reflect.ValueOf(impls)
+	if m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String {
+		panicf("RunAgainst should be given a map of implementations by name, was given %T", impls)
+	}
+	implType := m.Type().Elem()
+	fn := reflect.ValueOf(function)
+	if fn.Kind() != reflect.Func {
+		panicf("RunAgainst was not provided a function.")
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 3 || fnType.In(0) != testingTType || fnType.In(1) != implType || fnType.In(2) != tc.vType || fnType.NumOut() != 0 {
+		panicf("RunAgainst should be given a function of the form func(t *testing.T, impl %v, tc %v), was given %v", implType, tc.vType, fnType)
+	}
+	var names []string
+	for _, k := range m.MapKeys() {
+		names = append(names, k.String())
+	}
+	sort.Strings(names)
+	subtests := uniqueNames(names)
+	results := make(map[string]*Result, len(names))
+	failed := false
+	runType := reflect.FuncOf([]reflect.Type{testingTType, tc.vType}, nil, false)
+	for i, name := range names {
+		impl := m.MapIndex(reflect.ValueOf(name).Convert(m.Type().Key()))
+		run := reflect.MakeFunc(runType, func(args []reflect.Value) []reflect.Value {
+			return fn.Call([]reflect.Value{args[0], impl, args[1]})
+		})
+		t.Run(subtests[i], func(t *testing.T) {
+			tc.RunT(t, run.Interface())
+		})
+		results[name] = tc.last
+		failed = failed || tc.last.Failed > 0
+	}
+	if failed {
+		t.Logf("Results of the test cases for each implementation:\n%v", tc.conformanceMatrix(names, results))
+	}
+	return results
+}
+
+// conformanceMatrix returns a table of the status of each test case, for each of the implementations.
+func (tc *Test) conformanceMatrix(names []string, results map[string]*Result) string {
+	status := make(map[string]map[int]Status, len(names))
+	for _, name := range names {
+		status[name] = make(map[int]Status)
+		for _, cr := range results[name].Cases {
+			status[name][cr.Index] = cr.Status
+		}
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	fmt.Fprint(w, "case")
+	for _, name := range names {
+		fmt.Fprintf(w, "\t%v", name)
+	}
+	fmt.Fprintln(w)
+	for idx := range tc.cases {
+		fmt.Fprintf(w, "%v %v", idx, tc.cases[idx].caseName(idx))
+		for _, name := range names {
+			s, ok := status[name][idx]
+			if !ok {
+				s = "-"
+			}
+			fmt.Fprintf(w, "\t%v", s)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	return buf.String()
+}
@@ -0,0 +1,56 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+type sorter interface {
+	Sort([]int) []int
+}
+
+type stdSort struct{}
+
+func (stdSort) Sort(s []int) []int {
+	s = append([]int(nil), s...)
+	sort.Ints(s)
+	return s
+}
+
+type insertionSort struct{}
+
+func (insertionSort) Sort(s []int) []int {
+	s = append([]int(nil), s...)
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j] < s[j-1]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+	return s
+}
+
+func TestRunAgainst(t *testing.T) {
+	test := tbltest.Cases([]int{3, 1, 2}, []int{}, []int{5, 5, 1})
+	var ran []string
+	results := test.RunAgainst(t, map[string]sorter{"std": stdSort{}, "insertion": insertionSort{}}, func(t *testing.T, s sorter, tc []int) {
+		ran = append(ran, t.Name())
+		if got := s.Sort(tc); !sort.IntsAreSorted(got) {
+			t.Errorf("expected %v to be sorted, got %v", tc, got)
+		}
+	})
+	if len(ran) != 6 || !strings.HasPrefix(ran[0], "TestRunAgainst/insertion/") || !strings.HasPrefix(ran[5], "TestRunAgainst/std/") {
+		t.Errorf("expected each test case to run for each implementation in order, got %v", ran)
+	}
+	for _, name := range []string{"std", "insertion"} {
+		if r := results[name]; r == nil || r.Passed != 3 {
+			t.Errorf("expected all the test cases of %v to pass, got %+v", name, r)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RunDiff calls both the reference and the candidate function with each test case, and fails the test cases
+// for which their results differ, as told by Equal, with the Diff of the results as the error. It is meant
+// for checking that a new implementation, such as an optimized one, matches the reference implementation
+// across the whole table. Both functions must be of the same form, one of:
+//
+//	func(tc $testcase) $result
+//	func(tc $testcase) ($result, error)
+//
+// With the second form, a test case also fails if only one of the functions returns an error; if both do,
+// the errors are not compared, and the test case passes. Otherwise RunDiff runs the table like Run, and
+// returns the number of test cases run.
+//
+//	test.RunDiff(parseSlow, parseFast)
+func (tc *Test) RunDiff(reference, candidate interface{}) int {
+	ref, cand := reflect.ValueOf(reference), reflect.ValueOf(candidate)
+	if ref.Kind() != reflect.Func || cand.Kind() != reflect.Func {
+		panicf("RunDiff should be given two functions, was given %T and %T", reference, candidate)
+	}
+	if ref.Type() != cand.Type() {
+		panicf("The functions given to RunDiff should be of the same type, was given %v and %v", ref.Type(), cand.Type())
+	}
+	fnType := ref.Type()
+	withErr := fnType.NumOut() == 2 && fnType.Out(1) == errorType
+	if fnType.NumIn() != 1 || fnType.In(0) != tc.vType || (fnType.NumOut() != 1 && !withErr) {
+		panicf("RunDiff should be given functions of the form func(tc %v) $result or func(tc %v) ($result, error), was given %v", tc.vType, tc.vType, fnType)
+	}
+	rn := tc.newCheckRunner(func(idx int, tcase TestCase) error {
+		in := []reflect.Value{reflect.ValueOf(tcase)}
+		want, got := ref.Call(in), cand.Call(in)
+		if withErr {
+			wantErr, _ := want[1].Interface().(error)
+			gotErr, _ := got[1].Interface().(error)
+			switch {
+			case wantErr != nil && gotErr != nil:
+				return nil
+			case wantErr != nil:
+				return fmt.Errorf("the reference failed with %v, but the candidate did not", wantErr)
+			case gotErr != nil:
+				return fmt.Errorf("the candidate failed with %v, but the reference did not", gotErr)
+			}
+		}
+		if diff := Diff(got[0].Interface(), want[0].Interface()); diff != "" {
+			return fmt.Errorf("the candidate (got) differs from the reference (want):\n%v", diff)
+		}
+		return nil
+	})
+	return tc.runWith(rn).Ran
+}
@@ -0,0 +1,55 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRunDiff(t *testing.T) {
+	test := tbltest.Cases("1", "22", "x", "-4")
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	reference := func(s string) (int, error) { return strconv.Atoi(s) }
+	candidate := func(s string) (int, error) {
+		n := 0
+		for _, c := range s {
+			if c < '0' || c > '9' {
+				return 0, errors.New("not a number")
+			}
+			n = n*10 + int(c-'0')
+		}
+		return n, nil
+	}
+	if count := test.RunDiff(reference, reference); count != 4 {
+		t.Errorf("expected to run 4 tests, ran %v instead", count)
+	}
+	for _, cr := range test.Results() {
+		if cr.Err != nil {
+			t.Errorf("expected a function not to differ from itself, got %v", cr.Err)
+		}
+	}
+
+	test.RunDiff(reference, candidate)
+	for _, cr := range test.Results() {
+		if failed := cr.Err != nil; failed != (cr.Index == 3) {
+			t.Errorf("expected only test case 3 to fail, test case %v failed with: %v", cr.Index, cr.Err)
+		}
+	}
+	if err := test.Results()[3].Err; err == nil || !strings.Contains(err.Error(), "the reference did not") {
+		t.Errorf("expected the candidate to fail where the reference did not, got %v", err)
+	}
+
+	lengths := tbltest.Cases("abc")
+	lengths.RunDiff(func(s string) []int { return []int{len(s)} }, func(s string) []int { return []int{len(s) + 1} })
+	if err := lengths.Results()[0].Err; err == nil || !strings.Contains(err.Error(), "[0]: got 4, want 3") {
+		t.Errorf("expected the diff of the results, got %v", err)
+	}
+}
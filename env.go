@@ -0,0 +1,108 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"os"
+	"reflect"
+	"strings"
+)
+
+// SetEnv sets the environment variable key to value while the test case runs, and restores it afterwards. With
+// RunT the variables are set with t.Setenv. The environment variables can also be given by the string fields of
+// the test case tagged with the name of the variable, as in `tbl:"env=HOME"`; those set with SetEnv take
+// precedence.
+//
+//	type testcase struct {
+//		home string `tbl:"env=HOME"`
+//		want string
+//	}
+//
+//	test := tbltest.Cases(
+//		tbltest.SetEnv(testcase{home: "/tmp/x", want: "/tmp/x/.config"}, "XDG_CONFIG_HOME", ""),
+//	)
+//
+// As the environment is shared by the whole process, tables using it should not be run in parallel.
+func SetEnv(tcase TestCase, key, value string) TestCase {
+	return mark(tcase, func(e *entry) {
+		env := make(map[string]string, len(e.env)+1)
+		for k, v := range e.env {
+			env[k] = v
+		}
+		env[key] = value
+		e.env = env
+	})
+}
+
+// WithEnv is an Option that sets the environment variable key to value while the test cases run (see SetEnv).
+func WithEnv(key, value string) Option {
+	return func(tcase TestCase) TestCase {
+		return SetEnv(tcase, key, value)
+	}
+}
+
+// environment returns the environment variables to set for the test case: those of its fields tagged with
+// `tbl:"env=NAME"`, and those set with SetEnv.
+func (e *entry) environment() map[string]string {
+	var env map[string]string
+	v := e.get()
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name := tagOptionValue(f, "env")
+			if name == "" || f.Type.Kind() != reflect.String {
+				continue
+			}
+			if env == nil {
+				env = make(map[string]string)
+			}
+			env[name] = v.Field(i).String()
+		}
+	}
+	if len(e.env) > 0 && env == nil {
+		return e.env
+	}
+	for k, val := range e.env {
+		env[k] = val
+	}
+	return env
+}
+
+// tagOptionValue returns the value of the option of the tbl struct tag of the field, as in `tbl:"env=HOME"`, or
+// the empty string if it is not set.
+func tagOptionValue(f reflect.StructField, option string) string {
+	for _, opt := range strings.Split(f.Tag.Get("tbl"), ",") {
+		if kv := strings.SplitN(strings.TrimSpace(opt), "=", 2); len(kv) == 2 && kv[0] == option {
+			return kv[1]
+		}
+	}
+	return ""
+}
+
+// setEnv sets the environment variables, and returns a function that restores them as they were.
+func setEnv(env map[string]string) (restore func()) {
+	type saved struct {
+		value string
+		ok    bool
+	}
+	old := make(map[string]saved, len(env))
+	for k, v := range env {
+		value, ok := os.LookupEnv(k)
+		old[k] = saved{value, ok}
+		os.Setenv(k, v)
+	}
+	return func() {
+		for k, s := range old {
+			if s.ok {
+				os.Setenv(k, s.value)
+			} else {
+				os.Unsetenv(k)
+			}
+		}
+	}
+}
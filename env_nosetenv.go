@@ -0,0 +1,16 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build !go1.17
+// +build !go1.17
+
+package tbltest
+
+import "testing"
+
+// setEnvT sets the environment variables for the rest of the test t. As t.Setenv was added in Go 1.17, they are
+// restored with t.Cleanup instead.
+func setEnvT(t *testing.T, env map[string]string) {
+	t.Cleanup(setEnv(env))
+}
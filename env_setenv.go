@@ -0,0 +1,17 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.17
+// +build go1.17
+
+package tbltest
+
+import "testing"
+
+// setEnvT sets the environment variables for the rest of the test t, with t.Setenv.
+func setEnvT(t *testing.T, env map[string]string) {
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestSetEnv(t *testing.T) {
+	type testcase struct {
+		home string `tbl:"env=TBLTEST_HOME"`
+		want string
+	}
+	os.Setenv("TBLTEST_HOME", "original")
+	defer os.Unsetenv("TBLTEST_HOME")
+	os.Unsetenv("TBLTEST_MODE")
+	test := tbltest.Cases(
+		testcase{home: "/tmp/a", want: "/tmp/a:"},
+		tbltest.SetEnv(testcase{home: "/tmp/b", want: "/tmp/b:fast"}, "TBLTEST_MODE", "fast"),
+		tbltest.Group("override", []tbltest.Option{tbltest.WithEnv("TBLTEST_HOME", "/tmp/c")},
+			testcase{home: "/tmp/b", want: "/tmp/c:"},
+		),
+	)
+	check := func(tc testcase) bool {
+		return os.Getenv("TBLTEST_HOME")+":"+os.Getenv("TBLTEST_MODE") == tc.want
+	}
+	if n := test.Run(check); n != 3 {
+		t.Errorf("expected all 3 test cases to pass, got %v", n)
+	}
+	test.RunT(t, func(t *testing.T, tc testcase) {
+		if !check(tc) {
+			t.Errorf("expected the environment %q, got %q:%q", tc.want, os.Getenv("TBLTEST_HOME"), os.Getenv("TBLTEST_MODE"))
+		}
+	})
+	if got := os.Getenv("TBLTEST_HOME"); got != "original" {
+		t.Errorf("expected TBLTEST_HOME to be restored, got %q", got)
+	}
+	if _, ok := os.LookupEnv("TBLTEST_MODE"); ok {
+		t.Errorf("expected TBLTEST_MODE to be unset again")
+	}
+}
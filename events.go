@@ -0,0 +1,14 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+// eventLogger is given the events of the runs of a table, by the test function named test, to log as
+// structured records.
+type eventLogger interface {
+	runStarted(test string, cases int, seed int64)
+	caseStarted(test string, idx int, name string)
+	caseFinished(test string, r CaseResult)
+	runFinished(test string, r *Result)
+}
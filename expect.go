@@ -0,0 +1,36 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "fmt"
+
+// ExpectCases declares how many test cases the table holds, so a long table that loses or gains test cases by
+// mistake, such as in a merge, is caught. Run panics, and TryRun and Validate return an error, if the table does
+// not hold n test cases.
+//
+//	test := tbltest.Cases(
+//		...
+//	).ExpectCases(120)
+func (tc *Test) ExpectCases(n int) *Test {
+	if n < 0 {
+		panicf("ExpectCases should be given a count of at least zero, was given %v", n)
+	}
+	tc.expectCases, tc.expectCount = n, true
+	return tc
+}
+
+// checkExpected checks the table holds the number of test cases given to ExpectCases, with the fingerprint given
+// to ExpectFingerprint.
+func (tc *Test) checkExpected() error {
+	if tc.expectCount && len(tc.cases) != tc.expectCases {
+		return fmt.Errorf("the table should hold %v test cases, as given to ExpectCases, but holds %v", tc.expectCases, len(tc.cases))
+	}
+	if tc.expectFingerprint != "" {
+		if fp := tc.Fingerprint(); fp != tc.expectFingerprint {
+			return fmt.Errorf("the test cases of the table changed: their fingerprint is %v, but %v was given to ExpectFingerprint; if the change is deliberate, give it the new fingerprint", fp, tc.expectFingerprint)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestExpectCases(t *testing.T) {
+	if n := tbltest.Cases(0, 1, 2).ExpectCases(3).Run(func(int) {}); n != 3 {
+		t.Errorf("expected 3 test cases to run, got %v", n)
+	}
+	_, err := tbltest.Cases(0, 1).ExpectCases(3).TryRun(func(int) {})
+	if err == nil || !strings.Contains(err.Error(), "should hold 3 test cases, as given to ExpectCases, but holds 2") {
+		t.Errorf("expected the count to be checked, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "should hold 3 test cases") {
+			t.Errorf("expected Run to panic on the count, got %v", r)
+		}
+	}()
+	tbltest.Cases(0, 1, 2, 3).ExpectCases(3).Run(func(int) {})
+}
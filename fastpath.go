@@ -0,0 +1,172 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+// fastFunc returns a function that calls the test function directly, without going through reflect.Call,
+// if the test function is one of the four forms for a test case of a basic type such as int or string;
+// otherwise it returns nil. Calling through reflection dominates the run time of tables with many cheap
+// test cases. The returned function reports whether the test case passed, and ok is false if the test case
+// was not of the type the test function takes, in which case the test function was not called.
+func fastFunc(function TestFunc) func(idx int, tc TestCase) (passed, ok bool) {
+	switch fn := function.(type) {
+	case func(int):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int)
+			if ok {
+				fn(v)
+			}
+			return true, ok
+		}
+	case func(int) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int)
+			return ok && fn(v), ok
+		}
+	case func(int, int):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int)
+			if ok {
+				fn(idx, v)
+			}
+			return true, ok
+		}
+	case func(int, int) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int)
+			return ok && fn(idx, v), ok
+		}
+	case func(int64):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int64)
+			if ok {
+				fn(v)
+			}
+			return true, ok
+		}
+	case func(int64) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int64)
+			return ok && fn(v), ok
+		}
+	case func(int, int64):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int64)
+			if ok {
+				fn(idx, v)
+			}
+			return true, ok
+		}
+	case func(int, int64) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(int64)
+			return ok && fn(idx, v), ok
+		}
+	case func(float64):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(float64)
+			if ok {
+				fn(v)
+			}
+			return true, ok
+		}
+	case func(float64) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(float64)
+			return ok && fn(v), ok
+		}
+	case func(int, float64):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(float64)
+			if ok {
+				fn(idx, v)
+			}
+			return true, ok
+		}
+	case func(int, float64) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(float64)
+			return ok && fn(idx, v), ok
+		}
+	case func(string):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(string)
+			if ok {
+				fn(v)
+			}
+			return true, ok
+		}
+	case func(string) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(string)
+			return ok && fn(v), ok
+		}
+	case func(int, string):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(string)
+			if ok {
+				fn(idx, v)
+			}
+			return true, ok
+		}
+	case func(int, string) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(string)
+			return ok && fn(idx, v), ok
+		}
+	case func(bool):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(bool)
+			if ok {
+				fn(v)
+			}
+			return true, ok
+		}
+	case func(bool) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(bool)
+			return ok && fn(v), ok
+		}
+	case func(int, bool):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(bool)
+			if ok {
+				fn(idx, v)
+			}
+			return true, ok
+		}
+	case func(int, bool) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.(bool)
+			return ok && fn(idx, v), ok
+		}
+	case func([]byte):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.([]byte)
+			if ok {
+				fn(v)
+			}
+			return true, ok
+		}
+	case func([]byte) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.([]byte)
+			return ok && fn(v), ok
+		}
+	case func(int, []byte):
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.([]byte)
+			if ok {
+				fn(idx, v)
+			}
+			return true, ok
+		}
+	case func(int, []byte) bool:
+		return func(idx int, tc TestCase) (bool, bool) {
+			v, ok := tc.([]byte)
+			return ok && fn(idx, v), ok
+		}
+	}
+	return nil
+}
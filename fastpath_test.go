@@ -0,0 +1,47 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRunBasicTypes(t *testing.T) {
+	var sum int
+	test := tbltest.Generate(100, func(idx int) int { return idx })
+	test.ContinueOnFailure = true
+	result := test.RunResult(func(idx, tc int) bool {
+		sum += tc
+		return idx == tc && tc != 50
+	})
+	if sum != 4950 || result.Failed != 1 {
+		t.Errorf("expected the cases to add up to 4950 with one failure, got %v and %+v", sum, result)
+	}
+
+	var got string
+	tbltest.Cases("a", "b").Add("c").Run(func(tc string) { got += tc })
+	if len(got) != 3 {
+		t.Errorf("expected all three string cases to run, got %q", got)
+	}
+
+	// A middleware passing on a value of another type gets the usual error.
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for a test case of the wrong type")
+		}
+	}()
+	tbltest.Cases(1).Use(func(next tbltest.CaseFunc) tbltest.CaseFunc {
+		return func(idx int, tc tbltest.TestCase) error { return next(idx, "1") }
+	}).Run(func(tc int) {})
+}
+
+func BenchmarkRun(b *testing.B) {
+	test := tbltest.Generate(b.N, func(idx int) int { return idx })
+	test.InOrder = true
+	b.ResetTimer()
+	test.Run(func(tc int) bool { return tc >= 0 })
+}
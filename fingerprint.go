@@ -0,0 +1,116 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Fingerprint returns a hash of the test cases of the table: of their order, names, types and values, including
+// unexported fields and what pointers point to. It changes when any test case is changed, added or removed, and
+// is the same across runs and machines otherwise. Funcs and channels are only hashed by their type. Test cases
+// that are generated randomly are generated to be hashed, so their fingerprint is only stable for a given
+// tblTest.Seed.
+func (tc *Test) Fingerprint() string {
+	h := sha256.New()
+	for i := range tc.cases {
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "%d %q ", i, tc.cases[i].name)
+		writeValue(&buf, tc.cases[i].get(), make(map[copyKey]bool))
+		buf.WriteByte('\n')
+		h.Write(buf.Bytes())
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// ExpectFingerprint declares the Fingerprint of the table, so the test cases of a table shared by a number of
+// packages, such as a conformance table, are only changed deliberately: Run panics, and TryRun and Validate
+// return an error, giving the new fingerprint, if the test cases changed.
+//
+//	var Conformance = tbltest.Cases(
+//		...
+//	).ExpectFingerprint("5c1fa9e0c2d37b48")
+func (tc *Test) ExpectFingerprint(fingerprint string) *Test {
+	tc.expectFingerprint = fingerprint
+	return tc
+}
+
+// writeValue writes v to buf, in a form that only depends on its type and contents. seen are the pointers
+// being written, to stop at cycles.
+func writeValue(buf *bytes.Buffer, v reflect.Value, seen map[copyKey]bool) {
+	if !v.IsValid() {
+		buf.WriteString("invalid")
+		return
+	}
+	fmt.Fprintf(buf, "%v(", v.Type())
+	defer buf.WriteByte(')')
+	switch v.Kind() {
+	case reflect.Bool:
+		fmt.Fprintf(buf, "%v", v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(buf, "%d", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprintf(buf, "%d", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(buf, "%x", math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		fmt.Fprintf(buf, "%x,%x", math.Float64bits(real(c)), math.Float64bits(imag(c)))
+	case reflect.String:
+		fmt.Fprintf(buf, "%q", v.String())
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("nil")
+			return
+		}
+		if v.Kind() == reflect.Ptr {
+			key := copyKey{ptr: v.Pointer(), t: v.Type()}
+			if seen[key] {
+				buf.WriteString("cycle")
+				return
+			}
+			seen[key] = true
+			defer delete(seen, key)
+		}
+		writeValue(buf, v.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("nil")
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			writeValue(buf, v.Index(i), seen)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteString("nil")
+			return
+		}
+		// Write the entries in the order of their written keys.
+		entries := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			var entry bytes.Buffer
+			writeValue(&entry, k, seen)
+			entry.WriteByte(':')
+			writeValue(&entry, v.MapIndex(k), seen)
+			entries = append(entries, entry.String())
+		}
+		sort.Strings(entries)
+		for _, entry := range entries {
+			buf.WriteString(entry)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fmt.Fprintf(buf, "%v:", v.Type().Field(i).Name)
+			writeValue(buf, v.Field(i), seen)
+		}
+	}
+}
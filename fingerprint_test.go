@@ -0,0 +1,44 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestFingerprint(t *testing.T) {
+	type node struct {
+		next  *node
+		attrs map[string]int
+	}
+	table := func(value int) *tbltest.Test {
+		n := &node{attrs: map[string]int{"a": 1, "b": 2, "c": value}}
+		n.next = n
+		return tbltest.Cases(*n, tbltest.Named(node{}, "empty"))
+	}
+	fp := table(3).Fingerprint()
+	if len(fp) != 16 {
+		t.Errorf("expected a fingerprint of 16 hex digits, got %q", fp)
+	}
+	for i := 0; i < 10; i++ {
+		if got := table(3).Fingerprint(); got != fp {
+			t.Fatalf("expected the fingerprint to be stable, got %v and %v", fp, got)
+		}
+	}
+	if got := table(4).Fingerprint(); got == fp {
+		t.Errorf("expected the fingerprint to change with an unexported map value")
+	}
+
+	if _, err := table(3).ExpectFingerprint(fp).TryRun(func(node) {}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	_, err := table(4).ExpectFingerprint(fp).TryRun(func(node) {})
+	if err == nil || !strings.Contains(err.Error(), "but "+fp+" was given to ExpectFingerprint") {
+		t.Errorf("expected the change to be an error, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"reflect"
+	"testing"
+)
+
+var fixtureType = reflect.TypeOf((*Fixture)(nil))
+
+// Fixture is given to each test case run by RunT with a function of the form
+//
+//	func(t *testing.T, fx *tbltest.Fixture, tc $testcase)
+//
+// or the same with a testing.TB, to hold what belongs to the test case alone: a temporary directory, and the
+// functions to clean up after it. Both end with the subtest of the test case, so the files written by one test
+// case are not seen by the next.
+type Fixture struct {
+	t   *testing.T
+	dir string
+}
+
+// Dir returns the temporary directory of the test case, which is created the first time it is asked for, and
+// removed with all it holds when the test case finishes.
+func (fx *Fixture) Dir() string {
+	if fx.dir == "" {
+		fx.dir = tempDir(fx.t)
+	}
+	return fx.dir
+}
+
+// Cleanup registers fn to be called when the test case finishes. Like t.Cleanup, the functions are called in
+// the reverse of the order they were registered in.
+func (fx *Fixture) Cleanup(fn func()) {
+	fx.t.Cleanup(fn)
+}
@@ -0,0 +1,25 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build !go1.15
+// +build !go1.15
+
+package tbltest
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// tempDir returns a temporary directory that is removed when t finishes. As t.TempDir was added in Go 1.15,
+// it is created with ioutil.TempDir instead.
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatalf("creating the temporary directory of the test case: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
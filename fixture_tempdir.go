@@ -0,0 +1,15 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.15
+// +build go1.15
+
+package tbltest
+
+import "testing"
+
+// tempDir returns a temporary directory that is removed when t finishes.
+func tempDir(t *testing.T) string {
+	return t.TempDir()
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestFixture(t *testing.T) {
+	test := tbltest.Cases("a", "b")
+	test.InOrder = true
+	var dirs []string
+	var cleaned []string
+	test.RunT(t, func(t *testing.T, fx *tbltest.Fixture, tc string) {
+		if fx.Dir() != fx.Dir() {
+			t.Errorf("expected the same directory each time it is asked for")
+		}
+		matches, _ := filepath.Glob(filepath.Join(fx.Dir(), "*"))
+		if len(matches) != 0 {
+			t.Errorf("expected an empty directory, got %v", matches)
+		}
+		if err := ioutil.WriteFile(filepath.Join(fx.Dir(), tc), []byte(tc), 0644); err != nil {
+			t.Fatal(err)
+		}
+		dirs = append(dirs, fx.Dir())
+		fx.Cleanup(func() { cleaned = append(cleaned, tc) })
+	})
+	if len(dirs) != 2 || dirs[0] == dirs[1] {
+		t.Fatalf("expected each test case to have a directory of its own, got %v", dirs)
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("expected %v to be removed, got %v", dir, err)
+		}
+	}
+	if len(cleaned) != 2 || cleaned[0] != "a" || cleaned[1] != "b" {
+		t.Errorf("expected the cleanup of each test case to run as it finished, got %v", cleaned)
+	}
+}
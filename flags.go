@@ -0,0 +1,89 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"flag"
+	"time"
+)
+
+// DefaultFlagPrefix is the prefix of the names of the command line flags, such as tblTest.RunOrder.
+const DefaultFlagPrefix = "tblTest."
+
+// flagDefs register each of the flags on a flag set, with the given prefix.
+var flagDefs []func(fs *flag.FlagSet, prefix string)
+
+// flagPrefix is the prefix the flags were last registered with.
+var flagPrefix = DefaultFlagPrefix
+
+// RegisterFlags registers the command line flags, named with DefaultFlagPrefix, on the flag set. Unless the
+// package is built with the tbltest_noflags build tag, they are registered on flag.CommandLine when the
+// package is initialized; programs that manage their own flags can build with the tag and register them where
+// they need to.
+func RegisterFlags(fs *flag.FlagSet) {
+	RegisterFlagsPrefix(fs, DefaultFlagPrefix)
+}
+
+// RegisterFlagsPrefix is like RegisterFlags, but names the flags with the given prefix. Log messages that
+// refer to the flags use the prefix the flags were last registered with. The options keep the values they
+// have, such as from flags parsed before, which become the defaults of the flags.
+//
+//	tbltest.RegisterFlagsPrefix(fs, "tbl-") // -tbl-RunOrder, -tbl-Tags, ...
+func RegisterFlagsPrefix(fs *flag.FlagSet, prefix string) {
+	flagPrefix = prefix
+	for _, def := range flagDefs {
+		def(fs, prefix)
+	}
+}
+
+// flagName returns the full name of the flag, e.g. tblTest.Seed for Seed.
+func flagName(name string) string {
+	return flagPrefix + name
+}
+
+func flagString(name string, value string, usage string) *string {
+	p := new(string)
+	*p = value
+	flagDefs = append(flagDefs, func(fs *flag.FlagSet, prefix string) {
+		fs.StringVar(p, prefix+name, *p, usage)
+	})
+	return p
+}
+
+func flagBool(name string, value bool, usage string) *bool {
+	p := new(bool)
+	*p = value
+	flagDefs = append(flagDefs, func(fs *flag.FlagSet, prefix string) {
+		fs.BoolVar(p, prefix+name, *p, usage)
+	})
+	return p
+}
+
+func flagInt(name string, value int, usage string) *int {
+	p := new(int)
+	*p = value
+	flagDefs = append(flagDefs, func(fs *flag.FlagSet, prefix string) {
+		fs.IntVar(p, prefix+name, *p, usage)
+	})
+	return p
+}
+
+func flagInt64(name string, value int64, usage string) *int64 {
+	p := new(int64)
+	*p = value
+	flagDefs = append(flagDefs, func(fs *flag.FlagSet, prefix string) {
+		fs.Int64Var(p, prefix+name, *p, usage)
+	})
+	return p
+}
+
+func flagDuration(name string, value time.Duration, usage string) *time.Duration {
+	p := new(time.Duration)
+	*p = value
+	flagDefs = append(flagDefs, func(fs *flag.FlagSet, prefix string) {
+		fs.DurationVar(p, prefix+name, *p, usage)
+	})
+	return p
+}
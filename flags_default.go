@@ -0,0 +1,14 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build !tbltest_noflags
+// +build !tbltest_noflags
+
+package tbltest
+
+import "flag"
+
+func init() {
+	RegisterFlags(flag.CommandLine)
+}
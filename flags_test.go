@@ -0,0 +1,53 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRegisterFlagsPrefix(t *testing.T) {
+	// The values given on the command line are kept to be set again afterwards, as is the prefix used in log
+	// messages.
+	values := make(map[string]string)
+	flag.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, tbltest.DefaultFlagPrefix) {
+			values[f.Name] = f.Value.String()
+		}
+	})
+	defer func() {
+		tbltest.RegisterFlags(flag.NewFlagSet("restore", flag.ContinueOnError))
+		for name, value := range values {
+			flag.Set(name, value)
+		}
+	}()
+
+	// Options set before the flags are registered keep their values.
+	flag.Set("tblTest.Tags", "fast")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	tbltest.RegisterFlagsPrefix(fs, "tbl-")
+	if got := fs.Lookup("tbl-Tags").Value.String(); got != "fast" {
+		t.Errorf("expected the tbl-Tags flag to keep the value fast, got %q", got)
+	}
+	fs.Set("tbl-Tags", "")
+
+	for _, name := range []string{"tbl-RunOrder", "tbl-Tags", "tbl-Seed", "tbl-V"} {
+		if fs.Lookup(name) == nil {
+			t.Errorf("expected the %v flag to be registered", name)
+		}
+	}
+	if err := fs.Parse([]string{"-tbl-RunOrder=2,0"}); err != nil {
+		t.Fatal(err)
+	}
+	var ran []int
+	tbltest.Cases(0, 1, 2).Run(func(tc int) { ran = append(ran, tc) })
+	if len(ran) != 2 || ran[0] != 2 || ran[1] != 0 {
+		t.Errorf("expected test cases 2 and 0 to run, got %v", ran)
+	}
+}
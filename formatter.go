@@ -0,0 +1,61 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// Formatter formats a failed test case, for the failures logged at the end of a run with ContinueOnFailure
+// set. The result may span several lines; they are indented under the summary of the run.
+type Formatter interface {
+	FormatFailure(r CaseResult) string
+}
+
+// DefaultFormatter is the Formatter used unless another one is set. It gives the index and name of the test
+// case, where it was defined, why it failed, and the command to rerun it; such as:
+//
+//	case 3 (empty input) defined at parse_test.go:12: test function returned false
+//		to rerun it: go test -run '^TestParse$' -args -tblTest.RunOrder=3
+type DefaultFormatter struct{}
+
+// FormatFailure formats the failed test case.
+func (DefaultFormatter) FormatFailure(r CaseResult) string {
+	var buf bytes.Buffer
+	buf.WriteString(colorize(ansiBold+ansiRed, fmt.Sprintf("case %v (%v)", r.Index, r.Name)))
+	if r.Source != "" {
+		fmt.Fprintf(&buf, " defined at %v", r.Source)
+	}
+	fmt.Fprintf(&buf, ": %v", errMessage(r.Err))
+	if r.Repro != "" {
+		fmt.Fprintf(&buf, "\n\tto rerun it: %v", r.Repro)
+	}
+	return buf.String()
+}
+
+var formatter struct {
+	sync.RWMutex
+	f Formatter
+}
+
+// SetFormatter sets the Formatter used for the tables that do not set their own, usually from TestMain, so the
+// failures are formatted the same way across the tests of a code base. A nil Formatter restores the
+// DefaultFormatter.
+func SetFormatter(f Formatter) {
+	formatter.Lock()
+	formatter.f = f
+	formatter.Unlock()
+}
+
+func defaultFormatter() Formatter {
+	formatter.RLock()
+	defer formatter.RUnlock()
+	if formatter.f == nil {
+		return DefaultFormatter{}
+	}
+	return formatter.f
+}
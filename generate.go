@@ -0,0 +1,35 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "reflect"
+
+// Generate creates a table of n test cases, that are created by calling gen with the index of the case.
+// gen must be of the form:
+//
+//	func(idx int) $testcase
+//
+// Each test case is only generated the first time it is run, so tables with many expensive test cases
+// only pay for the ones that are selected by the tblTest.RunOrder option. So they keep the same name
+// whether or not they have been run, the test cases are named by their index.
+func Generate(n int, gen interface{}) *Test {
+	fn := reflect.ValueOf(gen)
+	if fn.Kind() != reflect.Func {
+		panicf("Was not provided a generator function.")
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 1 || fnType.In(0) != reflect.TypeOf(int(1)) || fnType.NumOut() != 1 {
+		panicf("Generator function should be of the form func(idx int) $testcase, was given %v", fnType)
+	}
+	tc := Test{vType: fnType.Out(0)}
+	source := callerFileLine()
+	for i := 0; i < n; i++ {
+		idx := reflect.ValueOf(i)
+		tc.cases = append(tc.cases, entry{gen: func() reflect.Value {
+			return fn.Call([]reflect.Value{idx})[0]
+		}, lazy: true, source: source})
+	}
+	return &tc
+}
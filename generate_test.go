@@ -0,0 +1,38 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestGenerate(t *testing.T) {
+	type testcase struct {
+		val int
+	}
+	var generated []int
+	test := tbltest.Generate(1000, func(idx int) testcase {
+		generated = append(generated, idx)
+		return testcase{val: idx * 2}
+	})
+	if len(generated) != 0 {
+		t.Fatalf("expected no cases to be generated before running, got %v", generated)
+	}
+
+	test.RunOrder = "3,7,3"
+	count := test.Run(func(idx int, tc testcase) {
+		if tc.val != idx*2 {
+			t.Errorf("for test %v: expected %v, got %v", idx, idx*2, tc.val)
+		}
+	})
+	if count != 3 {
+		t.Errorf("expected to run 3 tests, ran %v instead", count)
+	}
+	if len(generated) != 2 || generated[0] != 3 || generated[1] != 7 {
+		t.Errorf("expected only cases 3 and 7 to be generated once, got %v", generated)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+// Package gherkin reads the scenarios of Gherkin feature files, the Given/When/Then format of BDD tools such
+// as cucumber, into the test cases of a github.com/gdey/tbltest table. Each example of a scenario outline is a
+// test case, so scenarios written with the product owners run as table driven tests, without a full cucumber
+// framework:
+//
+//	Feature: Checkout
+//
+//	  Scenario Outline: discounts
+//	    Given a cart worth <total>
+//	    When the code <code> is applied
+//	    Then the cart is worth <discounted>
+//
+//	    Examples:
+//	      | total | code   | discounted |
+//	      | 100   | HALF   | 50         |
+//	      | 100   | BOGUS  | 100        |
+//
+// Only the simple parts of Gherkin are understood: tags, backgrounds, scenarios, scenario outlines with
+// their examples, and the data tables and doc strings of steps. Rules are read as part of the feature.
+package gherkin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Feature is a parsed feature file.
+type Feature struct {
+	Name string
+	Tags []string
+	// Background are the steps run before each scenario.
+	Background []Step
+	Scenarios  []Scenario
+}
+
+// Scenario is a scenario, or a scenario outline, of a feature.
+type Scenario struct {
+	Name string
+	Tags []string
+	// Line is the line of the file the scenario starts at.
+	Line  int
+	Steps []Step
+	// Examples are the examples of a scenario outline, whose values fill the <placeholders> of the steps.
+	Examples []Examples
+}
+
+// Step is a Given, When, Then, And or But step of a scenario.
+type Step struct {
+	Keyword string
+	Text    string
+	Line    int
+	// Table is the data table of the step, if any, as rows of cells.
+	Table [][]string
+	// DocString is the doc string of the step, if any.
+	DocString string
+}
+
+// Examples is a table of examples of a scenario outline.
+type Examples struct {
+	Name   string
+	Tags   []string
+	Header []string
+	Rows   [][]string
+	// Lines are the lines of the file the rows are on.
+	Lines []int
+}
+
+var stepKeywords = []string{"Given ", "When ", "Then ", "And ", "But ", "* "}
+
+// ParseFile parses the named feature file.
+func ParseFile(filename string) (*Feature, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	feature, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%v:%v", filename, err)
+	}
+	return feature, nil
+}
+
+// Parse parses a feature file read from r. The errors start with the line they are about.
+func Parse(r io.Reader) (*Feature, error) {
+	var (
+		feature   Feature
+		tags      []string
+		steps     *[]Step
+		scenario  *Scenario
+		examples  *Examples
+		doc       *Step
+		docQuote  string
+		docIndent string
+		docLines  []string
+	)
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if doc != nil {
+			if line == docQuote {
+				doc.DocString = strings.Join(docLines, "\n")
+				doc, docLines = nil, nil
+			} else {
+				docLines = append(docLines, strings.TrimPrefix(s.Text(), docIndent))
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keyword, rest := "", line
+		if i := strings.Index(line, ":"); i >= 0 {
+			keyword, rest = line[:i], strings.TrimSpace(line[i+1:])
+		}
+		switch {
+		case strings.HasPrefix(line, "@"):
+			for _, t := range strings.Fields(line) {
+				tags = append(tags, strings.TrimPrefix(t, "@"))
+			}
+		case keyword == "Feature":
+			feature.Name, feature.Tags, tags = rest, tags, nil
+			steps, scenario, examples = nil, nil, nil
+		case keyword == "Rule":
+			tags, steps, scenario, examples = nil, nil, nil, nil
+		case keyword == "Background":
+			steps, scenario, examples = &feature.Background, nil, nil
+		case keyword == "Scenario" || keyword == "Example" || keyword == "Scenario Outline" || keyword == "Scenario Template":
+			feature.Scenarios = append(feature.Scenarios, Scenario{Name: rest, Tags: tags, Line: n})
+			scenario, tags, examples = &feature.Scenarios[len(feature.Scenarios)-1], nil, nil
+			steps = &scenario.Steps
+		case keyword == "Examples" || keyword == "Scenarios":
+			if scenario == nil {
+				return nil, fmt.Errorf("%v: examples outside of a scenario outline", n)
+			}
+			scenario.Examples = append(scenario.Examples, Examples{Name: rest, Tags: tags})
+			examples, tags, steps = &scenario.Examples[len(scenario.Examples)-1], nil, nil
+		case strings.HasPrefix(line, "|"):
+			cells := tableCells(line)
+			switch {
+			case examples != nil && examples.Header == nil:
+				examples.Header = cells
+			case examples != nil:
+				if len(cells) != len(examples.Header) {
+					return nil, fmt.Errorf("%v: the row has %v cells, the header has %v", n, len(cells), len(examples.Header))
+				}
+				examples.Rows = append(examples.Rows, cells)
+				examples.Lines = append(examples.Lines, n)
+			case steps != nil && len(*steps) > 0:
+				last := &(*steps)[len(*steps)-1]
+				last.Table = append(last.Table, cells)
+			default:
+				return nil, fmt.Errorf("%v: a table that is not part of a step or examples", n)
+			}
+		case strings.HasPrefix(line, `"""`) || strings.HasPrefix(line, "```"):
+			if steps == nil || len(*steps) == 0 {
+				return nil, fmt.Errorf("%v: a doc string that is not part of a step", n)
+			}
+			doc, docQuote = &(*steps)[len(*steps)-1], line[:3]
+			docIndent = s.Text()[:strings.Index(s.Text(), docQuote)]
+		default:
+			kw := stepKeyword(line)
+			if kw == "" {
+				// Free form descriptions.
+				continue
+			}
+			if steps == nil {
+				return nil, fmt.Errorf("%v: a step outside of a scenario or background", n)
+			}
+			*steps = append(*steps, Step{Keyword: strings.TrimSpace(kw), Text: strings.TrimSpace(line[len(kw):]), Line: n})
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if doc != nil {
+		return nil, fmt.Errorf("%v: the doc string is not closed", doc.Line)
+	}
+	return &feature, nil
+}
+
+func stepKeyword(line string) string {
+	for _, kw := range stepKeywords {
+		if strings.HasPrefix(line, kw) {
+			return kw
+		}
+	}
+	return ""
+}
+
+// tableCells splits a table row into its cells; a | in a cell is written as \|.
+func tableCells(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell []byte
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && (line[i+1] == '|' || line[i+1] == '\\'):
+			cell = append(cell, line[i+1])
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(string(cell)))
+			cell = cell[:0]
+		default:
+			cell = append(cell, line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(string(cell)))
+}
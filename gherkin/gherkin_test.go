@@ -0,0 +1,88 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package gherkin_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest/gherkin"
+)
+
+func TestParse(t *testing.T) {
+	feature, err := gherkin.ParseFile("testdata/checkout.feature")
+	if err != nil {
+		t.Fatalf("failed to parse the feature: %v", err)
+	}
+	if feature.Name != "Checkout" || !reflect.DeepEqual(feature.Tags, []string{"checkout"}) {
+		t.Errorf("unexpected feature %q, tagged %v", feature.Name, feature.Tags)
+	}
+	if len(feature.Background) != 1 || feature.Background[0].Text != "an empty cart" {
+		t.Errorf("unexpected background %+v", feature.Background)
+	}
+	if len(feature.Scenarios) != 3 {
+		t.Fatalf("expected 3 scenarios, got %v", len(feature.Scenarios))
+	}
+	outline := feature.Scenarios[1]
+	if len(outline.Examples) != 2 || len(outline.Examples[0].Rows) != 2 || outline.Examples[1].Name != "invalid codes" {
+		t.Errorf("unexpected examples %+v", outline.Examples)
+	}
+	if then := outline.Steps[2]; then.Keyword != "Then" || len(then.Table) != 2 {
+		t.Errorf("expected the Then step to have a table, got %+v", then)
+	}
+	if doc := feature.Scenarios[2].Steps[0].DocString; doc != "Total: 0\n  Thank you!" {
+		t.Errorf("unexpected doc string %q", doc)
+	}
+
+	for _, bad := range []string{
+		"Feature: x\n  Given a step outside of a scenario",
+		"Feature: x\n  Examples:\n    | a |",
+		"Feature: x\n  Scenario Outline: y\n    Examples:\n      | a | b |\n      | 1 |",
+		"Feature: x\n  Scenario: y\n    Given z\n      \"\"\"\n      open",
+	} {
+		if _, err := gherkin.Parse(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	type testcase struct {
+		Total      int
+		Discounted int
+		Steps      []gherkin.Step
+	}
+	test, err := gherkin.Load("testdata/checkout.feature", testcase{})
+	if err != nil {
+		t.Fatalf("failed to load the feature: %v", err)
+	}
+	if test.Len() != 5 {
+		t.Fatalf("expected 5 test cases, got %v", test.Len())
+	}
+	tc := test.Case(2).(testcase)
+	if tc.Total != 80 || tc.Discounted != 72 {
+		t.Errorf("unexpected test case %+v", tc)
+	}
+	if len(tc.Steps) != 4 || tc.Steps[2].Text != "the code TEN is applied" || tc.Steps[3].Table[1][1] != "72" {
+		t.Errorf("expected the placeholders of the steps to be replaced, got %+v", tc.Steps)
+	}
+	if name := test.Name(3); name != "discounts #3" {
+		t.Errorf("expected the fourth test case to be named discounts #3, got %v", name)
+	}
+	if tags := test.Tags(3); !reflect.DeepEqual(tags, []string{"checkout", "discounts", "invalid"}) {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	if empty := test.Case(0).(testcase); empty.Total != 0 || len(empty.Steps) != 2 {
+		t.Errorf("unexpected test case for a plain scenario %+v", empty)
+	}
+
+	type noSteps struct {
+		Total int
+	}
+	if _, err := gherkin.Load("testdata/checkout.feature", noSteps{}); err == nil {
+		t.Errorf("expected an error for columns that are not fields, without a Steps field")
+	}
+}
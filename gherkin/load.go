@@ -0,0 +1,154 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package gherkin
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gdey/tbltest"
+)
+
+var stepsType = reflect.TypeOf([]Step(nil))
+
+// Load reads the named feature file into a table, with a test case for each example of each scenario
+// outline, and for each scenario that is not an outline. The columns of the examples fill in the fields of
+// the test case as with tbltest.FromRows, and proto, a struct or a pointer to one, gives the type of the test
+// cases. If the struct has a Steps field of type []Step, it is set to the steps of the background and of the
+// scenario, with the <placeholders> replaced by the values of the example; the columns that are only used in
+// the steps then need no field of their own.
+//
+//	type testcase struct {
+//		Total, Discounted int
+//		Code              string
+//		Steps             []gherkin.Step
+//	}
+//
+//	test, err := gherkin.Load("testdata/checkout.feature", testcase{})
+//
+// Each test case is named after its scenario, followed by the number of its example, tagged with the tags of
+// the feature, scenario and examples, and recorded as defined at its line of the file.
+func Load(filename string, proto tbltest.TestCase) (*tbltest.Test, error) {
+	feature, err := ParseFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	vType := reflect.TypeOf(proto)
+	if vType == nil {
+		return nil, fmt.Errorf("proto is not a valid test case")
+	}
+	sType := vType
+	if sType.Kind() == reflect.Ptr {
+		sType = sType.Elem()
+	}
+	if sType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the test cases should be structs, not %v", vType)
+	}
+	stepsField, hasSteps := sType.FieldByName("Steps")
+	hasSteps = hasSteps && stepsField.Type == stepsType
+	test := tbltest.Cases()
+	add := func(v reflect.Value, steps []Step, name string, tags []string, line int) {
+		if hasSteps {
+			s := v
+			if s.Kind() == reflect.Ptr {
+				s = s.Elem()
+			} else {
+				s = reflect.New(v.Type()).Elem()
+				s.Set(v)
+				v = s
+			}
+			s.FieldByIndex(stepsField.Index).Set(reflect.ValueOf(steps))
+		}
+		tcase := tbltest.Tagged(v.Interface(), tags...)
+		tcase = tbltest.Named(tcase, name)
+		test.Add(tbltest.DefinedAt(tcase, fmt.Sprintf("%v:%v", filename, line)))
+	}
+	for _, sc := range feature.Scenarios {
+		steps := append(append([]Step{}, feature.Background...), sc.Steps...)
+		tags := append(append([]string{}, feature.Tags...), sc.Tags...)
+		if len(sc.Examples) == 0 {
+			v := reflect.New(sType)
+			if vType.Kind() != reflect.Ptr {
+				v = v.Elem()
+			}
+			add(v, steps, sc.Name, tags, sc.Line)
+			continue
+		}
+		n := 0
+		for _, ex := range sc.Examples {
+			header, values := ex.Header, ex.Rows
+			if hasSteps {
+				header, values = fieldColumns(sType, ex.Header, ex.Rows)
+			}
+			rows, err := tbltest.FromRows(header, values, proto)
+			if err != nil {
+				return nil, fmt.Errorf("%v:%v: %v", filename, sc.Line, err)
+			}
+			for i, row := range ex.Rows {
+				n++
+				add(reflect.ValueOf(rows.Case(i)), expandSteps(steps, ex.Header, row), fmt.Sprintf("%v #%v", sc.Name, n), append(append([]string{}, tags...), ex.Tags...), ex.Lines[i])
+			}
+		}
+	}
+	return test, nil
+}
+
+// fieldColumns returns the columns of the examples that name a field of the struct type t, or the test case.
+func fieldColumns(t reflect.Type, header []string, rows [][]string) ([]string, [][]string) {
+	key := func(s string) string { return strings.ToLower(strings.Replace(s, " ", "", -1)) }
+	var keep []int
+	for i, h := range header {
+		if key(h) == "name" {
+			keep = append(keep, i)
+			continue
+		}
+		for j := 0; j < t.NumField(); j++ {
+			if key(t.Field(j).Name) == key(h) {
+				keep = append(keep, i)
+				break
+			}
+		}
+	}
+	pick := func(cells []string) []string {
+		picked := make([]string, len(keep))
+		for i, k := range keep {
+			picked[i] = cells[k]
+		}
+		return picked
+	}
+	values := make([][]string, len(rows))
+	for i, row := range rows {
+		values[i] = pick(row)
+	}
+	return pick(header), values
+}
+
+// expandSteps returns a copy of the steps with the <placeholders> named by header replaced by the values of
+// the row.
+func expandSteps(steps []Step, header, row []string) []Step {
+	pairs := make([]string, 0, 2*len(header))
+	for i, h := range header {
+		pairs = append(pairs, "<"+h+">", row[i])
+	}
+	r := strings.NewReplacer(pairs...)
+	expanded := make([]Step, len(steps))
+	for i, s := range steps {
+		s.Text = r.Replace(s.Text)
+		s.DocString = r.Replace(s.DocString)
+		if s.Table != nil {
+			table := make([][]string, len(s.Table))
+			for j, cells := range s.Table {
+				table[j] = make([]string, len(cells))
+				for k, c := range cells {
+					table[j][k] = r.Replace(c)
+				}
+			}
+			s.Table = table
+		}
+		expanded[i] = s
+	}
+	return expanded
+}
@@ -0,0 +1,34 @@
+@checkout
+Feature: Checkout
+  The discounts applied to a cart.
+
+  Background:
+    Given an empty cart
+
+  Scenario: no items
+    Then the cart is worth 0
+
+  @discounts
+  Scenario Outline: discounts
+    Given items worth <total>
+    When the code <code> is applied
+    Then the cart is worth <discounted>
+      | code   | worth        |
+      | <code> | <discounted> |
+
+    Examples: valid codes
+      | total | code | discounted |
+      | 100   | HALF | 50         |
+      | 80    | TEN  | 72         |
+
+    @invalid
+    Examples: invalid codes
+      | total | code  | discounted |
+      | 100   | BOGUS | 100        |
+
+  Scenario: receipt
+    When the receipt is printed
+      """
+      Total: 0
+        Thank you!
+      """
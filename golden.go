@@ -0,0 +1,117 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var update = flagBool("Update", false, "Rewrite the golden files with the current results, instead of comparing against them.")
+
+// GoldenErrors compares the errors returned for test cases against golden files, one file per test case.
+// This is useful for APIs whose error text is part of the contract; for example a compiler or a command line tool.
+//
+//	golden := tbltest.GoldenErrors{Normalize: []func(string) string{tbltest.NormalizeAddresses}}
+//	test.Run(func(idx int, tc testcase) {
+//		_, err := Parse(tc.input)
+//		if err := golden.Check(test.Name(idx), err); err != nil {
+//			t.Error(err)
+//		}
+//	})
+//
+// When the tblTest.Update flag is given, the golden files are rewritten instead.
+type GoldenErrors struct {
+	// Dir is the directory the golden files are kept in. If empty, testdata is used.
+	Dir string
+	// Normalize is applied, in order, to each message in the error chain before it is written or compared.
+	// Use it to strip volatile fragments such as paths and addresses.
+	Normalize []func(string) string
+}
+
+// Path returns the path of the golden file for the named test case.
+func (g GoldenErrors) Path(name string) string {
+	dir := g.Dir
+	if dir == "" {
+		dir = "testdata"
+	}
+	return filepath.Join(dir, filepath.FromSlash(name)+".golden")
+}
+
+// Check compares err against the golden file for the named test case. The golden file holds the
+// error chain, one message per line; starting with err and followed by each error it wraps. A nil
+// error is recorded as an empty file.
+func (g GoldenErrors) Check(name string, err error) error {
+	got := g.format(err)
+	path := g.Path(name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, got, 0644)
+	}
+	want, rerr := ioutil.ReadFile(path)
+	if rerr != nil {
+		return fmt.Errorf("reading golden file for %v (run with -%v to create it): %v", name, flagName("Update"), rerr)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("error for %v does not match golden file %v (run with -%v to update it)\n%v\n%v",
+			name, path, flagName("Update"),
+			colorize(ansiRed, " got: "+string(bytes.TrimSpace(got))),
+			colorize(ansiGreen, "want: "+string(bytes.TrimSpace(want))))
+	}
+	return nil
+}
+
+// format returns the normalized error chain of err.
+func (g GoldenErrors) format(err error) []byte {
+	var buf bytes.Buffer
+	for ; err != nil; err = unwrap(err) {
+		msg := err.Error()
+		for _, fn := range g.Normalize {
+			msg = fn(msg)
+		}
+		buf.WriteString(msg)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// unwrap returns the error wrapped by err, if any.
+func unwrap(err error) error {
+	switch e := err.(type) {
+	case interface {
+		Unwrap() error
+	}:
+		return e.Unwrap()
+	case interface {
+		Cause() error
+	}:
+		if cause := e.Cause(); cause != err {
+			return cause
+		}
+	}
+	return nil
+}
+
+var addressRegexp = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+
+// NormalizeAddresses replaces hexadecimal addresses, such as 0xc000012345, with 0x?.
+func NormalizeAddresses(msg string) string {
+	return addressRegexp.ReplaceAllString(msg, "0x?")
+}
+
+// NormalizeReplace returns a normalizer that replaces every occurrence of old with new. This is
+// useful for removing machine specific fragments, such as the working or temporary directory.
+func NormalizeReplace(old, new string) func(string) string {
+	return func(msg string) string {
+		return strings.Replace(msg, old, new, -1)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+type wrappedError struct {
+	msg string
+	err error
+}
+
+func (e wrappedError) Error() string { return e.msg + ": " + e.err.Error() }
+func (e wrappedError) Unwrap() error { return e.err }
+
+func TestGoldenErrors(t *testing.T) {
+	golden := tbltest.GoldenErrors{
+		Dir: "testdata/golden",
+		Normalize: []func(string) string{
+			tbltest.NormalizeAddresses,
+			tbltest.NormalizeReplace("/home/gdey", "$HOME"),
+		},
+	}
+	type testcase struct {
+		name string
+		err  error
+		ok   bool
+	}
+	test := tbltest.Cases(
+		testcase{
+			name: "wrapped",
+			err:  wrappedError{msg: "open config", err: fmt.Errorf("bad pointer 0xc000012345 in /home/gdey/config")},
+			ok:   true,
+		},
+		testcase{
+			name: "nil",
+			ok:   true,
+		},
+		testcase{
+			name: "nil",
+			err:  errors.New("unexpected"),
+		},
+		testcase{
+			name: "missing",
+		},
+	)
+	test.Run(func(tc testcase) {
+		err := golden.Check(tc.name, tc.err)
+		if tc.ok && err != nil {
+			t.Errorf("for %v: unexpected error: %v", tc.name, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("for %v: expected a mismatch", tc.name)
+		}
+	})
+}
+
+func TestGoldenErrorsUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	flag.Set("tblTest.Update", "true")
+	defer flag.Set("tblTest.Update", "false")
+
+	golden := tbltest.GoldenErrors{Dir: dir}
+	if err := golden.Check("sub/case", errors.New("boom")); err != nil {
+		t.Fatalf("failed to update golden file: %v", err)
+	}
+	got, err := ioutil.ReadFile(golden.Path("sub/case"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "boom\n" {
+		t.Errorf("expected golden file to contain %q, got %q", "boom\n", got)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option is applied to each test case of a Group. Markers such as Quarantine can be used as options with a
+// function literal:
+//
+//	func(tc tbltest.TestCase) tbltest.TestCase { return tbltest.Quarantine(tc, "flaky on CI") }
+type Option func(tcase TestCase) TestCase
+
+// group is the test cases of a Group, already marked with its options and name. It is flattened into the
+// table when it is added.
+type group []TestCase
+
+// Group groups test cases that share the options, such as tags or a timeout, and names each test case after
+// the group, followed by a / and the name of the test case, or its index in the group. The group is flattened
+// when it is added to a table, and groups can be nested.
+//
+//	test := tbltest.Cases(
+//		tbltest.Group("unicode", []tbltest.Option{tbltest.WithTags("unicode")},
+//			testcase{input: "héllo"},
+//			tbltest.Named(testcase{input: "日本"}, "cjk"),
+//		),
+//		testcase{input: "plain"},
+//	)
+//
+// names the test cases unicode/0, unicode/cjk and 2.
+func Group(name string, opts []Option, testcases ...TestCase) TestCase {
+	var g group
+	add := func(i int, tcase TestCase) {
+		for _, opt := range opts {
+			tcase = opt(tcase)
+		}
+		g = append(g, mark(tcase, func(e *entry) {
+			e.name = name + "/" + e.caseName(i)
+		}))
+	}
+	for i, tcase := range testcases {
+		if inner, ok := tcase.(group); ok {
+			for _, c := range inner {
+				add(i, c)
+			}
+			continue
+		}
+		add(i, tcase)
+	}
+	return g
+}
+
+// flatten replaces the groups in testcases with the test cases in them.
+func flatten(testcases []TestCase) []TestCase {
+	flat := testcases[:0:0]
+	for _, tcase := range testcases {
+		if g, ok := tcase.(group); ok {
+			flat = append(flat, g...)
+			continue
+		}
+		flat = append(flat, tcase)
+	}
+	return flat
+}
+
+// WithTags is an Option that tags the test cases (see Tagged).
+func WithTags(tags ...string) Option {
+	return func(tcase TestCase) TestCase {
+		return Tagged(tcase, tags...)
+	}
+}
+
+// WithTimeout is an Option that fails a test case if the test function takes longer than d. The test
+// function keeps running in the background once it timed out, so it should not change anything the other
+// test cases depend on.
+func WithTimeout(d time.Duration) Option {
+	return func(tcase TestCase) TestCase {
+		return mark(tcase, func(e *entry) {
+			e.timeout = d
+		})
+	}
+}
+
+// WithSkip is an Option that skips the test cases, for the given reason, which is passed to the OnSkip hook.
+func WithSkip(reason string) Option {
+	return func(tcase TestCase) TestCase {
+		return mark(tcase, func(e *entry) {
+			e.skipReason = reason
+		})
+	}
+}
+
+// filterSkipped returns the indexes in list of the test cases that are not skipped by WithSkip.
+func (tc *Test) filterSkipped(list []int) []int {
+	var filtered []int
+	for _, idx := range list {
+		if idx >= 0 && idx < len(tc.cases) && tc.cases[idx].skipReason != "" {
+			tc.skip([]int{idx}, nil, tc.cases[idx].skipReason)
+			continue
+		}
+		filtered = append(filtered, idx)
+	}
+	return filtered
+}
+
+// withTimeout returns a CaseFunc that fails with an error if call takes longer than d.
+func withTimeout(call CaseFunc, d time.Duration) CaseFunc {
+	return func(idx int, tc TestCase) error {
+		type outcome struct {
+			err error
+			rec interface{}
+		}
+		done := make(chan outcome, 1)
+		go func() {
+			var o outcome
+			defer func() {
+				o.rec = recover()
+				done <- o
+			}()
+			o.err = call(idx, tc)
+		}()
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case o := <-done:
+			if o.rec != nil {
+				// Panic on the goroutine running the table, as the test case would without a timeout.
+				panic(o.rec)
+			}
+			return o.err
+		case <-timer.C:
+			return fmt.Errorf("timed out after %v", d)
+		}
+	}
+}
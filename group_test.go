@@ -0,0 +1,70 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestGroup(t *testing.T) {
+	test := tbltest.Cases(
+		tbltest.Group("unicode", []tbltest.Option{tbltest.WithTags("unicode")},
+			"héllo",
+			tbltest.Named("日本", "cjk"),
+			tbltest.Group("rtl", nil, "שלום"),
+		),
+		"plain",
+		tbltest.Tagged(tbltest.Group("skipped", []tbltest.Option{tbltest.WithSkip("not supported yet")}, "x"), "todo"),
+	)
+	var names []string
+	for i := 0; i < test.Len(); i++ {
+		names = append(names, test.Name(i))
+	}
+	if want := []string{"unicode/0", "unicode/cjk", "unicode/rtl/0", "3", "skipped/0"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected the names %v, got %v", want, names)
+	}
+	if tags := test.Tags(2); !reflect.DeepEqual(tags, []string{"unicode"}) {
+		t.Errorf("expected the nested group to be tagged unicode, got %v", tags)
+	}
+	if tags := test.Tags(4); !reflect.DeepEqual(tags, []string{"todo"}) {
+		t.Errorf("expected the marker on the group to tag its test cases, got %v", tags)
+	}
+
+	var skipped []string
+	test.OnSkip = func(idx int, name string, tc tbltest.TestCase, err error) {
+		skipped = append(skipped, name+": "+err.Error())
+	}
+	if count := test.Run(func(tc string) {}); count != 4 {
+		t.Errorf("expected to run 4 tests, ran %v instead", count)
+	}
+	if want := []string{"skipped/0: not supported yet"}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("expected the skipped test cases %v, got %v", want, skipped)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	test := tbltest.Cases(tbltest.Group("slow", []tbltest.Option{tbltest.WithTimeout(10 * time.Millisecond)}, time.Second, time.Duration(0)))
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	release := make(chan struct{})
+	defer close(release)
+	res := test.RunResult(func(d time.Duration) {
+		select {
+		case <-time.After(d):
+		case <-release:
+		}
+	})
+	if res.Failed != 1 || res.Cases[0].Err == nil || !strings.Contains(res.Cases[0].Err.Error(), "timed out after 10ms") {
+		t.Errorf("expected the first test case to time out, got %+v", res.Cases)
+	}
+	if res.Cases[1].Status != tbltest.StatusPass {
+		t.Errorf("expected the second test case to pass, got %v", res.Cases[1].Status)
+	}
+}
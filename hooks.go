@@ -0,0 +1,48 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "errors"
+
+// CaseHook is called with a test case, its index and name, and why it failed or was skipped; err is nil for a
+// test case that passed. tc is nil for a test case of Generate or Matrix that was not generated, as when it is
+// skipped; the hooks do not generate test cases that are not run.
+//
+//	test.OnFail = func(idx int, name string, tc tbltest.TestCase, err error) {
+//		saveArtifacts(name, tc.(testcase))
+//	}
+type CaseHook func(idx int, name string, tc TestCase, err error)
+
+// notify calls the hook for the status of the test case.
+func (rn *runner) notify(idx int, name string, e *entry, status Status, err error) {
+	hook := rn.onPass
+	if status.failed() {
+		hook = rn.onFail
+	}
+	if hook != nil {
+		hook(idx, name, e.generated(), err)
+	}
+}
+
+// skip calls the OnSkip hook for each of the test cases in before that were left out of after, for the given
+// reason. after must be a subsequence of before.
+func (tc *Test) skip(before, after []int, reason string) {
+	if tc.OnSkip == nil || *listCases {
+		return
+	}
+	err := errors.New(reason)
+	j := 0
+	for _, idx := range before {
+		if j < len(after) && after[j] == idx {
+			j++
+			continue
+		}
+		if idx < 0 || idx >= len(tc.cases) {
+			continue
+		}
+		e := &tc.cases[idx]
+		tc.OnSkip(idx, e.caseName(idx), e.generated(), err)
+	}
+}
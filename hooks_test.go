@@ -0,0 +1,72 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"sort"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestHooks(t *testing.T) {
+	flag.Set("tblTest.Tags", "-slow")
+	defer flag.Set("tblTest.Tags", "")
+
+	var passed, failed, skipped []int
+	test := tbltest.Cases(0, 1, tbltest.Tagged(2, "slow"), 3)
+	test.ContinueOnFailure = true
+	test.OnPass = func(idx int, name string, tc tbltest.TestCase, err error) {
+		passed = append(passed, tc.(int))
+	}
+	test.OnFail = func(idx int, name string, tc tbltest.TestCase, err error) {
+		if err == nil {
+			t.Errorf("expected an error for the failed case %v", idx)
+		}
+		failed = append(failed, tc.(int))
+	}
+	test.OnSkip = func(idx int, name string, tc tbltest.TestCase, err error) {
+		if err == nil || err.Error() != "excluded by the tblTest.Tags option" {
+			t.Errorf("unexpected reason for skipping case %v: %v", idx, err)
+		}
+		skipped = append(skipped, tc.(int))
+	}
+	test.Run(func(tc int) bool { return tc != 1 })
+
+	sort.Ints(passed)
+	if len(passed) != 2 || passed[0] != 0 || passed[1] != 3 {
+		t.Errorf("expected cases 0 and 3 to pass, got %v", passed)
+	}
+	if len(failed) != 1 || failed[0] != 1 {
+		t.Errorf("expected case 1 to fail, got %v", failed)
+	}
+	if len(skipped) != 1 || skipped[0] != 2 {
+		t.Errorf("expected case 2 to be skipped, got %v", skipped)
+	}
+}
+
+func TestSkipDoesNotGenerate(t *testing.T) {
+	defer flag.Set("tblTest.Shard", "")
+	flag.Set("tblTest.Shard", "0/2")
+
+	var generated []int
+	test := tbltest.Generate(4, func(idx int) int {
+		generated = append(generated, idx)
+		return idx
+	})
+	test.InOrder = true
+	var skipped []tbltest.TestCase
+	test.OnSkip = func(idx int, name string, tc tbltest.TestCase, err error) {
+		skipped = append(skipped, tc)
+	}
+	test.Run(func(tc int) {})
+	if len(generated) != 2 || generated[0] != 0 || generated[1] != 2 {
+		t.Errorf("expected only the test cases run to be generated, got %v", generated)
+	}
+	if len(skipped) != 2 || skipped[0] != nil || skipped[1] != nil {
+		t.Errorf("expected the skipped test cases to be passed as nil, got %v", skipped)
+	}
+}
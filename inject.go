@@ -0,0 +1,61 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+)
+
+var randType = reflect.TypeOf((*rand.Rand)(nil))
+
+// injectFunc returns function as a function of the form func(idx int, tc $testcase), with the results of
+// function, if it takes a Clock or a *rand.Rand in place of the index; otherwise function is returned as is.
+//
+// With a *rand.Rand, each test case is given one seeded from the seed of the run and its index, so the random
+// choices a test case makes are the same whatever order the test cases run in, and are made again with the
+// tblTest.Seed option. seed then returns that seed, once the first test case has been given its source, for
+// the commands that rerun the test cases; otherwise seed is nil.
+func (tc *Test) injectFunc(function TestFunc) (injected TestFunc, seed func() int64) {
+	fn := reflect.ValueOf(function)
+	if fn.Kind() != reflect.Func || fn.Type().NumIn() != 2 {
+		return function, nil
+	}
+	fnType := fn.Type()
+	var param func(idx int) reflect.Value
+	switch fnType.In(0) {
+	case clockType:
+		param = func(int) reflect.Value { return reflect.ValueOf(tc.caseClock()) }
+	case randType:
+		// The seed is only chosen, and logged, once the first test case runs.
+		var once sync.Once
+		var s int64
+		param = func(idx int) reflect.Value {
+			once.Do(func() {
+				s = newSeed()
+				logf("The test cases are given random sources seeded from %v; use -%v=%[1]v to reproduce.", s, flagName("Seed"))
+			})
+			return reflect.ValueOf(caseRand(s, idx))
+		}
+		seed = func() int64 { return s }
+	default:
+		return function, nil
+	}
+	outs := make([]reflect.Type, fnType.NumOut())
+	for i := range outs {
+		outs[i] = fnType.Out(i)
+	}
+	adapter := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{reflect.TypeOf(0), fnType.In(1)}, outs, false), func(args []reflect.Value) []reflect.Value {
+		return fn.Call([]reflect.Value{param(int(args[0].Int())), args[1]})
+	})
+	return adapter.Interface(), seed
+}
+
+// caseRand returns the random source of the test case at index idx, for a run with the given seed.
+func caseRand(seed int64, idx int) *rand.Rand {
+	// Spread the indexes apart, so the sources of neighbouring test cases are not seeded alike.
+	return rand.New(rand.NewSource(seed ^ int64(uint64(idx+1)*0x9e3779b97f4a7c15)))
+}
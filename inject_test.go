@@ -0,0 +1,58 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRandParam(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	flag.Set("tblTest.Seed", "42")
+	defer flag.Set("tblTest.Seed", "0")
+
+	run := func(inOrder bool) map[int]int64 {
+		test := tbltest.Cases(0, 1, 2, 3)
+		test.InOrder = inOrder
+		got := make(map[int]int64)
+		test.Run(func(r *rand.Rand, tc int) { got[tc] = r.Int63() })
+		return got
+	}
+	first, second := run(true), run(false)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected each test case to get the same random values whatever the order, got %v and %v", first, second)
+	}
+	if first[0] == first[1] {
+		t.Errorf("expected the test cases to get different random values, got %v", first)
+	}
+	if !strings.Contains(buf.String(), "seeded from 42; use -tblTest.Seed=42 to reproduce") {
+		t.Errorf("expected the seed to be logged, got %q", buf.String())
+	}
+}
+
+func TestRandParamRepro(t *testing.T) {
+	flag.Set("tblTest.Seed", "42")
+	defer flag.Set("tblTest.Seed", "0")
+
+	test := tbltest.Cases(0, 1)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	result := test.RunResult(func(r *rand.Rand, tc int) bool { return tc == 0 })
+	want := "go test -run '^TestRandParamRepro$' -args -tblTest.RunOrder=1 -tblTest.Seed=42"
+	if cr := result.Cases[1]; cr.Repro != want {
+		t.Errorf("expected the command to rerun case 1 to be %q, got %q", want, cr.Repro)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"reflect"
+)
+
+// IsolateCases makes the table pass a deep copy of the test case to each call of the test function, so a test
+// function that changes the slices, maps or pointers of a test case can not change other test cases that share
+// them, or the test case seen by a later retry or concurrent run of it. The copy includes unexported fields;
+// funcs and channels are shared.
+func (tc *Test) IsolateCases() *Test {
+	tc.isolate = true
+	return tc
+}
+
+// copyKey identifies a pointer that was copied, by its address and type.
+type copyKey struct {
+	ptr uintptr
+	t   reflect.Type
+}
+
+// deepCopy returns a copy of v that shares no memory with it, other than through funcs, channels and unsafe
+// pointers. copied maps the pointers already copied to their copies, so cycles are kept.
+func deepCopy(v reflect.Value, copied map[copyKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{v.Pointer(), v.Type()}
+		if c, ok := copied[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		copied[key] = c
+		c.Elem().Set(deepCopy(v.Elem(), copied))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), copied))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < c.NumField(); i++ {
+			f := settableField(c, i)
+			f.Set(deepCopy(f, copied))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), copied))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), copied))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMap(v.Type())
+		for _, k := range v.MapKeys() {
+			c.SetMapIndex(deepCopy(k, copied), deepCopy(v.MapIndex(k), copied))
+		}
+		return c
+	}
+	return v
+}
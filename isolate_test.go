@@ -0,0 +1,42 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+type node struct {
+	values []int
+	labels map[string]int
+	next   *node
+}
+
+func TestIsolateCases(t *testing.T) {
+	shared := []int{1, 2, 3}
+	loop := &node{values: shared, labels: map[string]int{"a": 1}}
+	loop.next = loop
+
+	test := tbltest.Cases(loop, &node{values: shared})
+	test.InOrder = true
+	test.IsolateCases()
+	count := test.Run(func(idx int, tc *node) bool {
+		ok := tc.values[0] == 1
+		tc.values[0] = 100
+		if tc.labels != nil {
+			tc.labels["a"] = 100
+			ok = ok && tc.next == tc
+		}
+		return ok
+	})
+	if count != 2 {
+		t.Errorf("expected both test cases to pass, %v ran", count)
+	}
+	if shared[0] != 1 || loop.labels["a"] != 1 {
+		t.Errorf("expected the test cases not to be changed, got %v and %v", shared, loop.labels)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package tbltest
+
+import "iter"
+
+// All returns an iterator over the index and value of each test case, in the order they were added.
+// Test cases created by Generate are generated as they are reached.
+//
+//	for idx, tc := range test.All() {
+//		fmt.Println(test.Name(idx), tc.(testcase).input)
+//	}
+func (tc *Test) All() iter.Seq2[int, TestCase] {
+	return func(yield func(int, TestCase) bool) {
+		for i := range tc.cases {
+			if !yield(i, tc.cases[i].get().Interface()) {
+				return
+			}
+		}
+	}
+}
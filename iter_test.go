@@ -0,0 +1,31 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestAll(t *testing.T) {
+	test := tbltest.Cases(10, 11, 12, 13)
+	var seen []int
+	for idx, tc := range test.All() {
+		if tc.(int) != idx+10 {
+			t.Errorf("for test %v: expected %v, got %v", idx, idx+10, tc)
+		}
+		seen = append(seen, idx)
+		if idx == 2 {
+			break
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected to stop after 3 cases, saw %v", seen)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// leakWait is how long CheckLeaks waits for the goroutines started by a test case to exit.
+const leakWait = time.Second
+
+// CheckLeaks is a Middleware that fails a test case which leaves goroutines running after it returns. The
+// goroutines are given a moment to exit, and the error lists the stacks of the ones that did not; so the leak
+// is attributed to the test case that caused it, rather than the whole test.
+//
+//	test.Use(tbltest.CheckLeaks).Run(func(tc testcase) bool { ... })
+func CheckLeaks(next CaseFunc) CaseFunc {
+	return func(idx int, tc TestCase) error {
+		before := goroutines()
+		if err := next(idx, tc); err != nil {
+			return err
+		}
+		var leaked []string
+		deadline := time.Now().Add(leakWait)
+		for {
+			leaked = leaked[:0]
+			for id, stack := range goroutines() {
+				if _, ok := before[id]; !ok {
+					leaked = append(leaked, stack)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if len(leaked) > 0 {
+			return fmt.Errorf("leaked %v goroutines:\n\n%v", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+		return nil
+	}
+}
+
+// goroutines returns the stacks of the running goroutines, by their id. Goroutines started by the runtime
+// itself, such as the garbage collector's workers, are left out.
+func goroutines() map[string]string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	stacks := make(map[string]string)
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		s := strings.TrimSpace(string(stack))
+		// Each stack starts with a line like: goroutine 7 [running]:
+		fields := strings.Fields(s)
+		if len(fields) < 2 || fields[0] != "goroutine" {
+			continue
+		}
+		if strings.Contains(s, "\ncreated by runtime.") {
+			continue
+		}
+		stacks[fields[1]] = s
+	}
+	return stacks
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestCheckLeaks(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	test := tbltest.Cases(false, true, false)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	result := test.Use(tbltest.CheckLeaks).RunResult(func(leak bool) {
+		if leak {
+			go func() { <-done }()
+			return
+		}
+		// A goroutine that exits on its own is not a leak.
+		exited := make(chan struct{})
+		go func() { close(exited) }()
+		<-exited
+	})
+	if result.Failed != 1 {
+		t.Fatalf("expected one case to fail, got %+v", result)
+	}
+	cr := result.Cases[1]
+	if cr.Status != tbltest.StatusFail || cr.Err == nil || !strings.Contains(cr.Err.Error(), "leaked 1 goroutines") {
+		t.Errorf("expected case 1 to fail for leaking, got %v: %v", cr.Status, cr.Err)
+	}
+	if !strings.Contains(cr.Err.Error(), "TestCheckLeaks") {
+		t.Errorf("expected the leaked stack to be given, got %v", cr.Err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var listCases = flagBool("List", false, "List the test cases that would run, without running them.")
+var listFormat = flagString("ListFormat", "text", "The format of the tblTest.List listing: text, or json for tools, with each test case as a line of JSON starting with \""+listPrefix+"\".")
+
+// listPrefix starts each line of a listing in the json format, so tools can tell the listing from anything else
+// the tests write.
+const listPrefix = "tbltest.case "
+
+// listedCase is a test case in a listing in the json format.
+type listedCase struct {
+	Test string `json:"test"`
+	// Table is the name the table was registered under, if it was; see Register.
+	Table  string   `json:"table,omitempty"`
+	Index  int      `json:"index"`
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags,omitempty"`
+	Source string   `json:"source,omitempty"`
+}
+
+// list writes a line for each of the test cases in list, with the name of the test, and the index, name, tags
+// and definition site of the test case; separated by tabs, or as JSON with the tblTest.ListFormat option, which
+// also gives the name the table was registered under.
+func (tc *Test) list(w io.Writer, list []int) {
+	if *listFormat != "text" && *listFormat != "json" {
+		panicf("Invalid value %q for %v, expected text or json.", *listFormat, flagName("ListFormat"))
+	}
+	test, table := callerTestName(), tc.registeredName()
+	for _, idx := range list {
+		if idx < 0 || idx >= len(tc.cases) {
+			continue
+		}
+		e := &tc.cases[idx]
+		if *listFormat == "json" {
+			data, err := json.Marshal(listedCase{Test: test, Table: table, Index: idx, Name: e.caseName(idx), Tags: e.tags, Source: e.source})
+			if err != nil {
+				panicf("Could not list test case %v: %v", idx, err)
+			}
+			fmt.Fprintf(w, "%v%s\n", listPrefix, data)
+			continue
+		}
+		tags := "-"
+		if len(e.tags) > 0 {
+			tags = strings.Join(e.tags, ",")
+		}
+		source := e.source
+		if source == "" {
+			source = "n/a"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", test, idx, e.caseName(idx), tags, source)
+	}
+}
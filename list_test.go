@@ -0,0 +1,88 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestList(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	flag.Set("tblTest.List", "true")
+	defer func() {
+		flag.Set("tblTest.List", "false")
+		os.Stdout = stdout
+	}()
+
+	_, _, line, _ := runtime.Caller(0)
+	test := tbltest.Cases(tbltest.Tagged(1, "a", "b"), 2)
+	test.InOrder = true
+	ran := test.Run(func(tc int) {
+		t.Errorf("test case %v ran while listing", tc)
+	})
+	w.Close()
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ran != 0 {
+		t.Errorf("expected no test cases to run, %v did", ran)
+	}
+	want := fmt.Sprintf("TestList\t0\t0\ta,b\tlist_test.go:%[1]v\nTestList\t1\t1\t-\tlist_test.go:%[1]v\n", line+1)
+	if string(out) != want {
+		t.Errorf("unexpected listing:\n%q\nwanted:\n%q", out, want)
+	}
+}
+
+func TestListJSON(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	flag.Set("tblTest.List", "true")
+	flag.Set("tblTest.ListFormat", "json")
+	defer func() {
+		flag.Set("tblTest.List", "false")
+		flag.Set("tblTest.ListFormat", "text")
+		os.Stdout = stdout
+	}()
+
+	_, _, line, _ := runtime.Caller(0)
+	test := tbltest.Cases(tbltest.Tagged(tbltest.Named(1, "tab\there"), "a"))
+	test.Run(func(tc int) {
+		t.Errorf("test case %v ran while listing", tc)
+	})
+	registeredA.Run(func(tc int) {
+		t.Errorf("test case %v ran while listing", tc)
+	})
+	w.Close()
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf(`tbltest.case {"test":"TestListJSON","index":0,"name":"tab\there","tags":["a"],"source":"list_test.go:%v"}`+"\n", line+1)
+	if !strings.HasPrefix(string(out), want) {
+		t.Errorf("unexpected listing:\n%q\nwanted it to start with:\n%q", out, want)
+	}
+	// The listing of a registered table gives its name.
+	if want := `tbltest.case {"test":"TestListJSON","table":"registry_test/a","index":0,"name":"0",`; !strings.Contains(string(out), want) {
+		t.Errorf("unexpected listing:\n%q\nwanted it to contain:\n%q", out, want)
+	}
+}
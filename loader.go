@@ -0,0 +1,216 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+)
+
+// UnmarshalFunc describes a function that decodes data into the value pointed to by v.
+// json.Unmarshal, and the Unmarshal functions of the common YAML packages, have this form.
+type UnmarshalFunc func(data []byte, v interface{}) error
+
+// FromJSON reads the test cases from the named JSON file. The file should contain an
+// array of test cases, each element is decoded into a new value of the same type as proto.
+//
+//	test, err := tbltest.FromJSON("testdata/cases.json", testcase{})
+//
+// As with encoding/json, only the exported fields of the test case are filled in.
+func FromJSON(filename string, proto TestCase) (*Test, error) {
+	return loadCases(filename, jsonUnmarshal, proto)
+}
+
+// FromYAML reads the test cases from the named YAML file. The file should contain a
+// sequence of test cases, each element is decoded into a new value of the same type as proto.
+//
+// This package does not depend on a YAML implementation; unmarshal does the actual decoding and
+// is normally yaml.Unmarshal, from either gopkg.in/yaml.v2 or gopkg.in/yaml.v3. Both resolve
+// anchors, aliases and merge keys before decoding, so common fragments can be shared between
+// cases:
+//
+//	# testdata/cases.yaml
+//	- &base
+//	  Host: localhost
+//	  Port: 80
+//	- <<: *base
+//	  Port: 8080
+//
+// which is loaded with:
+//
+//	test, err := tbltest.FromYAML("testdata/cases.yaml", yaml.Unmarshal, testcase{})
+//
+// To reject fields that are not in the test case type, as FromJSONStrict does, use yaml.UnmarshalStrict from
+// gopkg.in/yaml.v2, or a yaml.v3 Decoder with KnownFields set; their errors give the line of each problem.
+func FromYAML(filename string, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	if unmarshal == nil {
+		return nil, errors.New("FromYAML requires an unmarshal function")
+	}
+	return loadCases(filename, unmarshal, proto)
+}
+
+func loadCases(filename string, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	return readCases(ioutil.ReadFile, filename, unmarshal, proto)
+}
+
+// readCases reads the named file with readFile, and decodes the test cases in it.
+func readCases(readFile func(string) ([]byte, error), filename string, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	data, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCases(filename, data, unmarshal, proto)
+}
+
+// decodeCases decodes data into a slice of proto's type, and creates a Test from the elements of that slice.
+func decodeCases(filename string, data []byte, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	vType := reflect.TypeOf(proto)
+	if vType == nil {
+		return nil, errors.New("proto is not a valid test case")
+	}
+	cases := reflect.New(reflect.SliceOf(vType))
+	if err := unmarshal(data, cases.Interface()); err != nil {
+		return nil, fmt.Errorf("decoding test cases from %v: %v", filename, err)
+	}
+	tc := Test{vType: vType}
+	for i := 0; i < cases.Elem().Len(); i++ {
+		tc.cases = append(tc.cases, entry{value: cases.Elem().Index(i), source: filename})
+	}
+	return &tc, nil
+}
+
+// FromDir creates a test case for each file in the testdata directory that matches the glob pattern.
+// The contents of each file are passed to decode, which must be of the form:
+//
+//	func(data []byte) ($testcase, error)
+//
+// Each test case is named after its file, relative to the testdata directory, and the cases are
+// added in lexical order of the file names. It is an error for no file to match, as with a mistyped pattern.
+//
+//	test, err := tbltest.FromDir("parse/*.input", func(data []byte) (testcase, error) {
+//		return testcase{input: string(data)}, nil
+//	})
+func FromDir(glob string, decode interface{}) (*Test, error) {
+	pattern := filepath.Join("testdata", glob)
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %q", pattern)
+	}
+	return decodeFiles(ioutil.ReadFile, files, func(file string) (string, error) {
+		name, err := filepath.Rel("testdata", file)
+		return filepath.ToSlash(name), err
+	}, decode)
+}
+
+// decodeFiles creates a test case, named by nameOf, for each of the files using the decode function.
+func decodeFiles(readFile func(string) ([]byte, error), files []string, nameOf func(string) (string, error), decode interface{}) (*Test, error) {
+	fn := reflect.ValueOf(decode)
+	if err := checkDecodeFunc(fn); err != nil {
+		return nil, err
+	}
+	return decodeEach(readFile, files, nameOf, fn.Type().Out(0), func(data []byte) (reflect.Value, error) {
+		res := fn.Call([]reflect.Value{reflect.ValueOf(data)})
+		err, _ := res[1].Interface().(error)
+		return res[0], err
+	})
+}
+
+// decodeEach creates a test case of type vType, named by nameOf, for each of the files using decode.
+func decodeEach(readFile func(string) ([]byte, error), files []string, nameOf func(string) (string, error), vType reflect.Type, decode func([]byte) (reflect.Value, error)) (*Test, error) {
+	tc := Test{vType: vType}
+	for _, file := range files {
+		data, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		name, err := nameOf(file)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding test case from %v: %v", file, err)
+		}
+		tc.cases = append(tc.cases, entry{value: value, name: name, source: file})
+	}
+	return &tc, nil
+}
+
+// FromMessages creates a test case for each file in the testdata directory that matches the glob pattern,
+// decoding each file into a new value of the same type as proto with unmarshal. It is meant for test cases
+// kept as protocol buffer messages, in the text format or binary, which can not be decoded into a slice as
+// FromJSON does; proto is then a pointer to the generated message type, and unmarshal is one of:
+//
+//	func(data []byte, v interface{}) error { return prototext.Unmarshal(data, v.(proto.Message)) }
+//	func(data []byte, v interface{}) error { return proto.Unmarshal(data, v.(proto.Message)) }
+//
+// As with FromYAML, this package does not depend on a protocol buffer implementation. The test cases are
+// named and ordered as with FromDir, and it is an error for no file to match.
+//
+//	test, err := tbltest.FromMessages("vectors/*.txtpb", unmarshal, &pb.Case{})
+func FromMessages(glob string, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	pattern := filepath.Join("testdata", glob)
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %q", pattern)
+	}
+	return decodeMessages(ioutil.ReadFile, files, func(file string) (string, error) {
+		name, err := filepath.Rel("testdata", file)
+		return filepath.ToSlash(name), err
+	}, unmarshal, proto)
+}
+
+// decodeMessages creates a test case, named by nameOf, for each of the files by unmarshaling it into a new
+// value of proto's type. If proto is a pointer, the value it points to is unmarshaled into.
+func decodeMessages(readFile func(string) ([]byte, error), files []string, nameOf func(string) (string, error), unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	if unmarshal == nil {
+		return nil, errors.New("FromMessages requires an unmarshal function")
+	}
+	vType := reflect.TypeOf(proto)
+	if vType == nil {
+		return nil, errors.New("proto is not a valid test case")
+	}
+	return decodeEach(readFile, files, nameOf, vType, func(data []byte) (reflect.Value, error) {
+		if vType.Kind() == reflect.Ptr {
+			v := reflect.New(vType.Elem())
+			return v, unmarshal(data, v.Interface())
+		}
+		v := reflect.New(vType)
+		return v.Elem(), unmarshal(data, v.Interface())
+	})
+}
+
+// jsonUnmarshal is the UnmarshalFunc used by the JSON loaders.
+var jsonUnmarshal UnmarshalFunc = json.Unmarshal
+
+var (
+	bytesType = reflect.TypeOf([]byte(nil))
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// checkDecodeFunc makes sure fn is of the form func([]byte) ($testcase, error).
+func checkDecodeFunc(fn reflect.Value) error {
+	if fn.Kind() != reflect.Func {
+		return errors.New("decode should be a function of the form func([]byte) ($testcase, error)")
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 1 || fnType.In(0) != bytesType {
+		return fmt.Errorf("decode should take a single []byte parameter, was given %v", fnType)
+	}
+	if fnType.NumOut() != 2 || fnType.Out(1) != errorType {
+		return fmt.Errorf("decode should return a test case and an error, was given %v", fnType)
+	}
+	return nil
+}
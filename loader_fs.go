@@ -0,0 +1,90 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package tbltest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// The loaders in this file read from an fs.FS instead of the working directory, so test cases can
+// be compiled into the test binary with go:embed, and the tests work from any working directory.
+//
+//	//go:embed testdata
+//	var testdata embed.FS
+//
+//	test, err := tbltest.FromJSONFS(testdata, "testdata/cases.json", testcase{})
+
+// FromJSONFS is like FromJSON, but reads the named file from fsys.
+func FromJSONFS(fsys fs.FS, filename string, proto TestCase) (*Test, error) {
+	return readCases(readFileFS(fsys), filename, jsonUnmarshal, proto)
+}
+
+// FromJSONStrictFS is like FromJSONStrict, but reads the named file from fsys.
+func FromJSONStrictFS(fsys fs.FS, filename string, proto TestCase) (*Test, error) {
+	return readCasesStrict(readFileFS(fsys), filename, proto)
+}
+
+// FromKATFS is like FromKAT, but reads the named file from fsys.
+func FromKATFS(fsys fs.FS, filename string, proto TestCase) (*Test, error) {
+	return readKAT(readFileFS(fsys), filename, proto)
+}
+
+// FromMarkdownFS is like FromMarkdown, but reads the named file from fsys.
+func FromMarkdownFS(fsys fs.FS, filename string, proto TestCase) (*Test, error) {
+	return readMarkdown(readFileFS(fsys), filename, proto)
+}
+
+// FromYAMLFS is like FromYAML, but reads the named file from fsys.
+func FromYAMLFS(fsys fs.FS, filename string, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	if unmarshal == nil {
+		return nil, errors.New("FromYAMLFS requires an unmarshal function")
+	}
+	return readCases(readFileFS(fsys), filename, unmarshal, proto)
+}
+
+// FromDirFS is like FromDir, but matches the glob pattern against the files in fsys. As fsys has
+// no testdata directory of its own, each case is named by its full path in fsys; use fs.Sub to
+// get the same names as FromDir. As with FromDir, it is an error for no file to match.
+//
+//	sub, _ := fs.Sub(testdata, "testdata")
+//	test, err := tbltest.FromDirFS(sub, "parse/*.input", decode)
+func FromDirFS(fsys fs.FS, glob string, decode interface{}) (*Test, error) {
+	files, err := fs.Glob(fsys, glob)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %q", glob)
+	}
+	return decodeFiles(readFileFS(fsys), files, func(file string) (string, error) {
+		return file, nil
+	}, decode)
+}
+
+// FromMessagesFS is like FromMessages, but matches the glob pattern against the files in fsys, naming each
+// test case by its full path in fsys as FromDirFS does.
+func FromMessagesFS(fsys fs.FS, glob string, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	files, err := fs.Glob(fsys, glob)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %q", glob)
+	}
+	return decodeMessages(readFileFS(fsys), files, func(file string) (string, error) {
+		return file, nil
+	}, unmarshal, proto)
+}
+
+func readFileFS(fsys fs.FS) func(string) ([]byte, error) {
+	return func(name string) ([]byte, error) {
+		return fs.ReadFile(fsys, name)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package tbltest_test
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestFromFS(t *testing.T) {
+	test, err := tbltest.FromJSONFS(testdata, "testdata/cases.json", nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load JSON cases: %v", err)
+	}
+	if count := test.Run(func(tc nameCase) {}); count != 3 {
+		t.Errorf("expected to run 3 JSON tests, ran %v instead", count)
+	}
+
+	test, err = tbltest.FromYAMLFS(testdata, "testdata/cases.json", json.Unmarshal, nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load YAML cases: %v", err)
+	}
+	if count := test.Run(func(tc nameCase) {}); count != 3 {
+		t.Errorf("expected to run 3 YAML tests, ran %v instead", count)
+	}
+
+	sub, err := fs.Sub(testdata, "testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test, err = tbltest.FromDirFS(sub, "dir/*.input", func(data []byte) (string, error) {
+		return string(data), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to load dir cases: %v", err)
+	}
+	test.InOrder = true
+	var names []string
+	test.Run(func(idx int, tc string) {
+		names = append(names, test.Name(idx))
+	})
+	if len(names) != 2 || names[0] != "dir/a.input" || names[1] != "dir/b.input" {
+		t.Errorf("expected cases named after the files, got %v", names)
+	}
+	if _, err := tbltest.FromMessagesFS(sub, "messages/*.txtpb", json.Unmarshal, &nameCase{}); err == nil || err.Error() != `no files match "messages/*.txtpb"` {
+		t.Errorf("expected an error for a pattern that matches no files, got %v", err)
+	}
+}
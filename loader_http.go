@@ -0,0 +1,127 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var offline = flagBool("Offline", false, "Load the test cases of FromURL from the copies cached in "+fixtureDir+", without fetching them.")
+
+// fixtureDir is where the files fetched by FromURL are cached, relative to the package directory.
+const fixtureDir = ".tbl/fixtures"
+
+// fixtureClient is the client FromURL fetches the files with.
+var fixtureClient = &http.Client{Timeout: 30 * time.Second}
+
+// FromURL fetches the test cases from a file published over HTTP or HTTPS, such as the conformance vectors of
+// a standard, and decodes them like FromYAML; if unmarshal is nil the file is decoded as JSON.
+//
+//	test, err := tbltest.FromURL("https://example.com/vectors/v2.json", nil, testcase{})
+//
+// Each file fetched is cached in .tbl/fixtures in the package directory, and fetched again only if it
+// changed, as told by its ETag or Last-Modified headers. If the file can not be fetched, the cached copy is
+// used instead, so the tests still run offline once the file has been fetched. With the tblTest.Offline
+// option the cached copy is always used, and it is an error if there is none.
+func FromURL(url string, unmarshal UnmarshalFunc, proto TestCase) (*Test, error) {
+	if unmarshal == nil {
+		unmarshal = jsonUnmarshal
+	}
+	return readCases(fetchFixture, url, unmarshal, proto)
+}
+
+// fixtureMeta is what is recorded about a cached file, to ask whether it changed.
+type fixtureMeta struct {
+	URL          string
+	ETag         string `json:",omitempty"`
+	LastModified string `json:",omitempty"`
+}
+
+// fixturePath returns the path of the cached copy of the file at url, without an extension.
+func fixturePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(filepath.FromSlash(fixtureDir), hex.EncodeToString(sum[:]))
+}
+
+// fetchFixture returns the contents of the file at url, from the cache if it did not change.
+func fetchFixture(url string) ([]byte, error) {
+	path := fixturePath(url)
+	cached, cacheErr := ioutil.ReadFile(path + ".data")
+	var meta fixtureMeta
+	if cacheErr == nil {
+		if data, err := ioutil.ReadFile(path + ".json"); err == nil {
+			json.Unmarshal(data, &meta)
+		}
+	}
+	if *offline {
+		if cacheErr != nil {
+			return nil, fmt.Errorf("%v has not been cached, and the %v option is set", url, flagName("Offline"))
+		}
+		return cached, nil
+	}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cacheErr == nil {
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+	resp, err := fixtureClient.Do(req)
+	if err != nil {
+		if cacheErr == nil {
+			logf("Using the cached copy of %v, it could not be fetched: %v", url, err)
+			return cached, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cacheErr == nil:
+		return cached, nil
+	case resp.StatusCode == http.StatusOK:
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %v: %v", url, err)
+		}
+		meta = fixtureMeta{URL: url, ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+		if err := cacheFixture(path, data, meta); err != nil {
+			logf("Failed to cache %v: %v", url, err)
+		}
+		return data, nil
+	}
+	if cacheErr == nil {
+		logf("Using the cached copy of %v, fetching it failed: %v", url, resp.Status)
+		return cached, nil
+	}
+	return nil, fmt.Errorf("fetching %v: %v", url, resp.Status)
+}
+
+// cacheFixture writes the fetched file, and what is needed to ask whether it changed, to the cache.
+func cacheFixture(path string, data []byte, meta fixtureMeta) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	m, err := json.MarshalIndent(meta, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".data", data, 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+".json", m, 0644)
+}
@@ -0,0 +1,77 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestFromURL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	var fetched, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fetched++
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"First": "Gautam", "Last": "Dey", "Expected": "Gautam Dey"}, {"First": "Jane", "Last": "Doe", "Expected": "Jane Doe"}]`))
+	}))
+	url := srv.URL + "/cases.json"
+
+	load := func() int {
+		test, err := tbltest.FromURL(url, nil, nameCase{})
+		if err != nil {
+			t.Fatalf("failed to load cases: %v", err)
+		}
+		return test.Len()
+	}
+	if n := load(); n != 2 {
+		t.Errorf("expected 2 test cases, got %v", n)
+	}
+	if n := load(); n != 2 {
+		t.Errorf("expected 2 test cases from the cache, got %v", n)
+	}
+	if fetched != 1 || notModified != 1 {
+		t.Errorf("expected the file to be fetched once and then not modified, got %v and %v", fetched, notModified)
+	}
+
+	// Once the server is gone, the cached copy is used.
+	srv.Close()
+	if n := load(); n != 2 {
+		t.Errorf("expected 2 test cases from the cache while offline, got %v", n)
+	}
+
+	flag.Set("tblTest.Offline", "true")
+	defer flag.Set("tblTest.Offline", "false")
+	if n := load(); n != 2 {
+		t.Errorf("expected 2 test cases from the cache with tblTest.Offline, got %v", n)
+	}
+	if _, err := tbltest.FromURL(srv.URL+"/other.json", nil, nameCase{}); err == nil {
+		t.Errorf("expected an error for a file that was never cached with tblTest.Offline")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+)
+
+// FromKAT reads the test cases from the named known answer test file, in the format NIST publishes test vectors
+// in, such as the .rsp files of the CAVP:
+//
+//	# SHA-1 ShortMsg
+//	[L = 20]
+//
+//	Len = 0
+//	Msg = 00
+//	MD = da39a3ee5e6b4b0d3255bfef95601890afd80709
+//
+//	Len = 8
+//	Msg = 36
+//	MD = c1dfd96eea8cc2b62785275bca38ac261256e278
+//
+// Each block of key = value lines, separated by blank lines, is a test case of proto's type, which must be a
+// struct or a pointer to one. A key fills in the field with the same name, ignoring case and spaces, as with
+// FromRows; []byte fields are decoded from hex. The [key = value] lines set the key for the blocks after them,
+// and the name of a [section] line is set in a string field named Section, if there is one. Keys that match no
+// field are ignored, so only the fields the test needs have to be declared, and lines starting with # are
+// comments.
+func FromKAT(filename string, proto TestCase) (*Test, error) {
+	return readKAT(ioutil.ReadFile, filename, proto)
+}
+
+func readKAT(readFile func(string) ([]byte, error), filename string, proto TestCase) (*Test, error) {
+	vType := reflect.TypeOf(proto)
+	if vType == nil {
+		return nil, errors.New("proto is not a valid test case")
+	}
+	sType := vType
+	if sType.Kind() == reflect.Ptr {
+		sType = sType.Elem()
+	}
+	if sType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the test cases should be structs, not %v", vType)
+	}
+	data, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]int, sType.NumField())
+	for i := 0; i < sType.NumField(); i++ {
+		fields[columnKey(sType.Field(i).Name)] = i
+	}
+	tc := Test{vType: vType}
+	// params are the keys set by the [key = value] and [section] lines, and block the test case being read.
+	params := make(map[string]string)
+	var block reflect.Value
+	var blockLine int
+	flush := func() {
+		if !block.IsValid() {
+			return
+		}
+		v := block
+		if vType.Kind() == reflect.Ptr {
+			v = v.Addr()
+		}
+		tc.cases = append(tc.cases, entry{value: v, source: fmt.Sprintf("%v:%v", filename, blockLine)})
+		block = reflect.Value{}
+	}
+	set := func(line int, key, value string) error {
+		i, ok := fields[columnKey(key)]
+		if !ok {
+			return nil
+		}
+		f := settableField(block, i)
+		if f.Type() == bytesType {
+			b, err := hex.DecodeString(value)
+			if err != nil {
+				return fmt.Errorf("decoding test cases from %v:%v: key %q: %v", filename, line, key, err)
+			}
+			f.SetBytes(b)
+			return nil
+		}
+		if err := setFromString(f, value); err != nil {
+			return fmt.Errorf("decoding test cases from %v:%v: key %q: %v", filename, line, key, err)
+		}
+		return nil
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			flush()
+			param := strings.TrimSpace(line[1 : len(line)-1])
+			if eq := strings.Index(param, "="); eq >= 0 {
+				params[strings.TrimSpace(param[:eq])] = strings.TrimSpace(param[eq+1:])
+			} else {
+				params["Section"] = param
+			}
+		default:
+			eq := strings.Index(line, "=")
+			if eq < 0 {
+				return nil, fmt.Errorf("decoding test cases from %v:%v: expected a key = value line, got %q", filename, i+1, line)
+			}
+			if !block.IsValid() {
+				block, blockLine = reflect.New(sType).Elem(), i+1
+				for key, value := range params {
+					if err := set(i+1, key, value); err != nil {
+						return nil, err
+					}
+				}
+			}
+			if err := set(i+1, strings.TrimSpace(line[:eq]), strings.TrimSpace(line[eq+1:])); err != nil {
+				return nil, err
+			}
+		}
+	}
+	flush()
+	return &tc, nil
+}
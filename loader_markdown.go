@@ -0,0 +1,145 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// FromMarkdown reads the test cases from the tables of the named Markdown file, so simple tables of inputs
+// and expected results can be written, and read, as documentation:
+//
+//	| name     | input | want |
+//	|----------|-------|------|
+//	| addition | 1+1   | 2    |
+//	| product  | `2*3` | 6    |
+//
+// The header row of each table names the fields of the test cases, and each row after the delimiter row is a
+// test case; the rows are parsed as with FromRows. A cell wrapped in backticks is taken without them, and a |
+// in a cell is written as \|. Text outside the tables is ignored; as when Markdown is rendered, that includes
+// lines starting with | that are not followed by a delimiter row with as many cells, and tables in fenced code
+// blocks.
+func FromMarkdown(filename string, proto TestCase) (*Test, error) {
+	return readMarkdown(ioutil.ReadFile, filename, proto)
+}
+
+func readMarkdown(readFile func(string) ([]byte, error), filename string, proto TestCase) (*Test, error) {
+	data, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var tc *Test
+	var header []string
+	var rows [][]string
+	var sources []string
+	flush := func() error {
+		if header == nil {
+			return nil
+		}
+		t, err := fromRows(header, rows, sources, proto)
+		if err != nil {
+			return fmt.Errorf("decoding test cases from %v: %v", filename, err)
+		}
+		if tc == nil {
+			tc = t
+		} else {
+			tc.cases = append(tc.cases, t.cases...)
+		}
+		header, rows, sources = nil, nil, nil
+		return nil
+	}
+	// fence is the fence of the code block the lines are in, if any.
+	var fence string
+	// candidate is the row before, which is the header of a table if this row is the delimiter row.
+	var candidate []string
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if fence != "" {
+			if f := codeFence(line); f != "" && f[0] == fence[0] && len(f) >= len(fence) && f == line {
+				fence = ""
+			}
+			continue
+		}
+		if !strings.HasPrefix(line, "|") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			candidate, fence = nil, codeFence(line)
+			continue
+		}
+		switch cells := markdownCells(line); {
+		case header != nil:
+			rows = append(rows, cells)
+			sources = append(sources, fmt.Sprintf("%v:%v", filename, i+1))
+		case candidate != nil && len(cells) == len(candidate) && isDelimiterRow(cells):
+			header, candidate = candidate, nil
+		default:
+			// Only a table if the delimiter row follows.
+			candidate = cells
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if tc == nil {
+		return nil, fmt.Errorf("no tables found in %v", filename)
+	}
+	return tc, nil
+}
+
+// codeFence returns the fence that line starts with, three or more backticks or tildes, if it does.
+func codeFence(line string) string {
+	for _, c := range []string{"`", "~"} {
+		if n := len(line) - len(strings.TrimLeft(line, c)); n >= 3 {
+			return line[:n]
+		}
+	}
+	return ""
+}
+
+// markdownCells splits a table row into its cells.
+func markdownCells(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell []byte
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell = append(cell, '|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, markdownCell(string(cell)))
+			cell = cell[:0]
+		default:
+			cell = append(cell, line[i])
+		}
+	}
+	return append(cells, markdownCell(string(cell)))
+}
+
+func markdownCell(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && strings.HasPrefix(s, "`") && strings.HasSuffix(s, "`") {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
+
+// isDelimiterRow reports whether the cells are those of the row between the header and the body of a table,
+// such as |---|:---:|.
+func isDelimiterRow(cells []string) bool {
+	for _, c := range cells {
+		c = strings.Trim(c, ":")
+		if c == "" || strings.Trim(c, "-") != "" {
+			return false
+		}
+	}
+	return true
+}
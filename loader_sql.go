@@ -0,0 +1,73 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var rowsType = reflect.TypeOf((*sql.Rows)(nil))
+
+// FromSQL creates a test case from each row returned by the query, so regression cases captured from
+// production data can be kept in a database rather than in the test. The query is run with the args, and
+// scan is called for each row; it must be of the form:
+//
+//	func(rows *sql.Rows) ($testcase, error)
+//
+// For example:
+//
+//	test, err := tbltest.FromSQL(ctx, db, "SELECT input, want FROM regressions WHERE service = ?", func(rows *sql.Rows) (testcase, error) {
+//		var tc testcase
+//		err := rows.Scan(&tc.input, &tc.want)
+//		return tc, err
+//	}, "billing")
+//
+// The test cases are in the order of the rows.
+func FromSQL(ctx context.Context, db *sql.DB, query string, scan interface{}, args ...interface{}) (*Test, error) {
+	if db == nil {
+		return nil, errors.New("FromSQL requires a database")
+	}
+	fn := reflect.ValueOf(scan)
+	if err := checkScanFunc(fn); err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying test cases: %v", err)
+	}
+	defer rows.Close()
+	source := callerFileLine()
+	tc := Test{vType: fn.Type().Out(0)}
+	for rows.Next() {
+		res := fn.Call([]reflect.Value{reflect.ValueOf(rows)})
+		if err, _ := res[1].Interface().(error); err != nil {
+			return nil, fmt.Errorf("scanning test case %v: %v", len(tc.cases), err)
+		}
+		tc.cases = append(tc.cases, entry{value: res[0], source: source})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading test cases: %v", err)
+	}
+	return &tc, nil
+}
+
+// checkScanFunc makes sure fn is of the form func(*sql.Rows) ($testcase, error).
+func checkScanFunc(fn reflect.Value) error {
+	if fn.Kind() != reflect.Func {
+		return errors.New("scan should be a function of the form func(*sql.Rows) ($testcase, error)")
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 1 || fnType.In(0) != rowsType {
+		return fmt.Errorf("scan should take a single *sql.Rows parameter, was given %v", fnType)
+	}
+	if fnType.NumOut() != 2 || fnType.Out(1) != errorType {
+		return fmt.Errorf("scan should return a test case and an error, was given %v", fnType)
+	}
+	return nil
+}
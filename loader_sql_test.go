@@ -0,0 +1,83 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+// fakeDriver is a database/sql driver whose queries all return the rows of regressions.
+type fakeDriver struct{}
+
+var regressions = [][]driver.Value{
+	{"Gautam", "Dey", "Gautam Dey"},
+	{"Jane", "Doe", "Jane Doe"},
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error                                    { return nil }
+func (fakeStmt) NumInput() int                                   { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error)  { return &fakeRows{}, nil }
+
+type fakeRows struct{ next int }
+
+func (*fakeRows) Columns() []string { return []string{"first", "last", "expected"} }
+func (*fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(regressions) {
+		return io.EOF
+	}
+	copy(dest, regressions[r.next])
+	r.next++
+	return nil
+}
+
+func init() {
+	sql.Register("tbltest-fake", fakeDriver{})
+}
+
+func TestFromSQL(t *testing.T) {
+	db, err := sql.Open("tbltest-fake", "")
+	if err != nil {
+		t.Fatalf("failed to open the database: %v", err)
+	}
+	defer db.Close()
+	test, err := tbltest.FromSQL(context.Background(), db, "SELECT first, last, expected FROM names", func(rows *sql.Rows) (nameCase, error) {
+		var tc nameCase
+		err := rows.Scan(&tc.First, &tc.Last, &tc.Expected)
+		return tc, err
+	})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	count := test.Run(func(tc nameCase) {
+		if got := tc.First + " " + tc.Last; got != tc.Expected {
+			t.Errorf("expected %q, got %q", tc.Expected, got)
+		}
+	})
+	if count != 2 {
+		t.Errorf("expected to run 2 tests, ran %v instead", count)
+	}
+
+	if _, err := tbltest.FromSQL(context.Background(), db, "SELECT 1", func(rows *sql.Rows) nameCase { return nameCase{} }); err == nil {
+		t.Errorf("expected an error for a scan function without an error result")
+	}
+}
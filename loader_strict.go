@@ -0,0 +1,229 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FromJSONStrict is like FromJSON, but fails if a test case has a field that is not in its type, such as a
+// misspelt field name, instead of silently leaving the field it was meant for as the zero value. The errors
+// give the file, line and test case of each unknown field and each value of the wrong type:
+//
+//	testdata/cases.json:3: test case 1 (empty input): unknown field "Exepcted" in tbltest_test.testcase
+//
+// A test case is named by its "name" field, if it has one. Fields of types that decode themselves, through
+// json.Unmarshaler or encoding.TextUnmarshaler, are not checked.
+func FromJSONStrict(filename string, proto TestCase) (*Test, error) {
+	return readCasesStrict(ioutil.ReadFile, filename, proto)
+}
+
+func readCasesStrict(readFile func(string) ([]byte, error), filename string, proto TestCase) (*Test, error) {
+	data, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	vType := reflect.TypeOf(proto)
+	if vType == nil {
+		return nil, errors.New("proto is not a valid test case")
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		if serr, ok := err.(*json.SyntaxError); ok {
+			return nil, fmt.Errorf("%v:%v: %v", filename, lineAt(data, int(serr.Offset)), err)
+		}
+		return nil, fmt.Errorf("decoding test cases from %v: %v", filename, err)
+	}
+	tc := Test{vType: vType}
+	var msgs []string
+	pos := 0
+	for i, raw := range raws {
+		// The elements are in the order of the file, so each one is found after the one before it.
+		off := pos + bytes.Index(data[pos:], raw)
+		pos = off + len(raw)
+		label := fmt.Sprintf("test case %v", i)
+		if name := jsonCaseName(raw); name != "" {
+			label += " (" + name + ")"
+		}
+		var errs []fieldError
+		checkJSONFields(raw, off, vType, &errs)
+		value := reflect.New(vType)
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			at := off
+			if terr, ok := err.(*json.UnmarshalTypeError); ok {
+				at += int(terr.Offset)
+			}
+			errs = append(errs, fieldError{at, err.Error()})
+		}
+		for _, e := range errs {
+			msgs = append(msgs, fmt.Sprintf("%v:%v: %v: %v", filename, lineAt(data, e.offset), label, e.msg))
+		}
+		tc.cases = append(tc.cases, entry{value: value.Elem(), source: fmt.Sprintf("%v:%v", filename, lineAt(data, off))})
+	}
+	if len(msgs) > 0 {
+		return nil, errors.New(strings.Join(msgs, "\n"))
+	}
+	return &tc, nil
+}
+
+// fieldError is a problem with the JSON value at offset in the file.
+type fieldError struct {
+	offset int
+	msg    string
+}
+
+// lineAt returns the line number of the byte at offset in data.
+func lineAt(data []byte, offset int) int {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return 1 + bytes.Count(data[:offset], []byte("\n"))
+}
+
+// jsonCaseName returns the "name" field of the JSON object raw, if it has one.
+func jsonCaseName(raw json.RawMessage) string {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(raw, &obj) != nil {
+		return ""
+	}
+	for k, v := range obj {
+		var name string
+		if strings.EqualFold(k, "name") && json.Unmarshal(v, &name) == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// checkJSONFields adds an error to errs for each field of the objects in raw, which is at offset in the file,
+// that is not a field of the struct type it is decoded into.
+func checkJSONFields(raw json.RawMessage, offset int, t reflect.Type, errs *[]fieldError) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			return
+		}
+		fields := jsonFields(t)
+		for _, k := range sortedJSONKeys(obj) {
+			at := offset
+			if key, err := json.Marshal(k); err == nil {
+				if i := bytes.Index(raw, key); i >= 0 {
+					at += i
+				}
+			}
+			ft, ok := lookupJSONField(fields, k)
+			if !ok {
+				*errs = append(*errs, fieldError{at, fmt.Sprintf("unknown field %q in %v", k, t)})
+				continue
+			}
+			checkJSONFields(obj[k], offset+bytes.Index(raw, obj[k]), ft, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		var elems []json.RawMessage
+		if json.Unmarshal(raw, &elems) != nil {
+			return
+		}
+		pos := 0
+		for _, elem := range elems {
+			off := pos + bytes.Index(raw[pos:], elem)
+			pos = off + len(elem)
+			checkJSONFields(elem, offset+off, t.Elem(), errs)
+		}
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			return
+		}
+		for _, k := range sortedJSONKeys(obj) {
+			checkJSONFields(obj[k], offset+bytes.Index(raw, obj[k]), t.Elem(), errs)
+		}
+	}
+}
+
+// jsonField is a field of a struct, as named in JSON.
+type jsonField struct {
+	name string
+	t    reflect.Type
+}
+
+// jsonFields returns the fields of the struct type t that encoding/json decodes into, including the fields of
+// embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			et := f.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(et)...)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			// Unexported.
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{name, f.Type})
+	}
+	return fields
+}
+
+// lookupJSONField returns the type of the field named key, which matches case insensitively, as in encoding/json.
+func lookupJSONField(fields []jsonField, key string) (reflect.Type, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f.t, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f.t, true
+		}
+	}
+	return nil, false
+}
+
+func sortedJSONKeys(obj map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
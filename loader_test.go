@@ -0,0 +1,213 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+type nameCase struct {
+	First    string
+	Last     string
+	Expected string
+}
+
+func TestFromJSON(t *testing.T) {
+	test, err := tbltest.FromJSON("testdata/cases.json", nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	count := test.Run(func(tc nameCase) {
+		if got := tc.First + " " + tc.Last; got != tc.Expected {
+			t.Errorf("expected %q, got %q", tc.Expected, got)
+		}
+	})
+	if count != 3 {
+		t.Errorf("expected to run 3 tests, ran %v instead", count)
+	}
+
+	if _, err := tbltest.FromJSON("testdata/missing.json", nameCase{}); err == nil {
+		t.Errorf("expected an error for a missing file.")
+	}
+}
+
+func TestFromYAML(t *testing.T) {
+	// JSON is a subset of YAML, so the JSON decoder stands in for a YAML package here.
+	var called bool
+	unmarshal := func(data []byte, v interface{}) error {
+		called = true
+		return json.Unmarshal(data, v)
+	}
+	test, err := tbltest.FromYAML("testdata/cases.json", unmarshal, nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	if !called {
+		t.Errorf("expected the unmarshal function to be called.")
+	}
+	if count := test.Run(func(tc nameCase) {}); count != 3 {
+		t.Errorf("expected to run 3 tests, ran %v instead", count)
+	}
+
+	if _, err := tbltest.FromYAML("testdata/cases.json", nil, nameCase{}); err == nil {
+		t.Errorf("expected an error for a nil unmarshal function.")
+	}
+	if _, err := tbltest.FromMessages("messages/*.txtpb", json.Unmarshal, &nameCase{}); err == nil || !strings.Contains(err.Error(), "no files match") {
+		t.Errorf("expected an error for a pattern that matches no files, got %v", err)
+	}
+}
+
+func TestFromDir(t *testing.T) {
+	type testcase struct {
+		input string
+	}
+	test, err := tbltest.FromDir("dir/*.input", func(data []byte) (testcase, error) {
+		return testcase{input: string(data)}, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	expected := map[string]string{
+		"dir/a.input": "hello",
+		"dir/b.input": "world!",
+	}
+	count := test.Run(func(idx int, tc testcase) {
+		name := test.Name(idx)
+		if expected[name] != tc.input {
+			t.Errorf("for %v: expected %q, got %q", name, expected[name], tc.input)
+		}
+	})
+	if count != 2 {
+		t.Errorf("expected to run 2 tests, ran %v instead", count)
+	}
+
+	if _, err := tbltest.FromDir("dir/*.input", func(data string) testcase { return testcase{} }); err == nil {
+		t.Errorf("expected an error for an invalid decode function.")
+	}
+	if _, err := tbltest.FromDir("dir/*.inptu", func(data []byte) (testcase, error) { return testcase{}, nil }); err == nil || !strings.Contains(err.Error(), "no files match") {
+		t.Errorf("expected an error for a pattern that matches no files, got %v", err)
+	}
+}
+
+func TestFromMessages(t *testing.T) {
+	// JSON stands in for the protocol buffer text format here.
+	test, err := tbltest.FromMessages("messages/*.msg", json.Unmarshal, &nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	count := test.Run(func(idx int, tc *nameCase) {
+		if got := tc.First + " " + tc.Last; got != tc.Expected {
+			t.Errorf("for %v: expected %q, got %q", test.Name(idx), tc.Expected, got)
+		}
+	})
+	if count != 2 {
+		t.Errorf("expected to run 2 tests, ran %v instead", count)
+	}
+	if name := test.Name(1); name != "messages/b.msg" {
+		t.Errorf("expected the second test case to be named messages/b.msg, got %v", name)
+	}
+
+	values, err := tbltest.FromMessages("messages/*.msg", json.Unmarshal, nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	if tc := values.Case(0).(nameCase); tc.Expected != "Gautam Dey" {
+		t.Errorf("expected the first test case to be decoded, got %+v", tc)
+	}
+	if _, err := tbltest.FromMessages("messages/*.msg", nil, &nameCase{}); err == nil {
+		t.Errorf("expected an error for a nil unmarshal function.")
+	}
+}
+
+func TestFromMarkdown(t *testing.T) {
+	test, err := tbltest.FromMarkdown("testdata/cases.md", nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	test.InOrder = true
+	var names []string
+	count := test.Run(func(idx int, tc nameCase) {
+		names = append(names, test.Name(idx))
+		if got := strings.TrimSpace(tc.First + " " + tc.Last); got != tc.Expected {
+			t.Errorf("for %v: expected %q, got %q", test.Name(idx), tc.Expected, got)
+		}
+	})
+	if count != 4 {
+		t.Errorf("expected to run 4 tests, ran %v instead", count)
+	}
+	if want := "author"; len(names) == 0 || names[0] != want {
+		t.Errorf("expected the first test case to be named %v, got %v", want, names)
+	}
+
+	if _, err := tbltest.FromMarkdown("testdata/cases.json", nameCase{}); err == nil {
+		t.Errorf("expected an error for a file without tables.")
+	}
+}
+
+func TestFromJSONStrict(t *testing.T) {
+	test, err := tbltest.FromJSONStrict("testdata/cases.json", nameCase{})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	if count := test.Run(func(tc nameCase) {}); count != 3 {
+		t.Errorf("expected to run 3 tests, ran %v instead", count)
+	}
+
+	type namedCase struct {
+		Name string
+		nameCase
+	}
+	_, err = tbltest.FromJSONStrict("testdata/strict.json", namedCase{})
+	if err == nil {
+		t.Fatalf("expected the unknown field and the type mismatch to be errors")
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected two errors, got %q", err)
+	}
+	if want := `testdata/strict.json:3: test case 1 (typo): unknown field "Lsat" in `; !strings.HasPrefix(lines[0], want) {
+		t.Errorf("expected the first error to start with %q, got %q", want, lines[0])
+	}
+	if want := `testdata/strict.json:5: test case 2: `; !strings.HasPrefix(lines[1], want) {
+		t.Errorf("expected the second error to start with %q, got %q", want, lines[1])
+	}
+	// FromJSON ignores the unknown field, but fails on the type mismatch too.
+	if _, err := tbltest.FromJSON("testdata/strict.json", nameCase{}); err == nil {
+		t.Errorf("expected FromJSON to fail on the type mismatch")
+	}
+}
+
+func TestFromKAT(t *testing.T) {
+	type vector struct {
+		L       int
+		Section string
+		Len     int
+		Msg     []byte
+		MD      []byte
+	}
+	test, err := tbltest.FromKAT("testdata/sha1.rsp", vector{})
+	if err != nil {
+		t.Fatalf("failed to load cases: %v", err)
+	}
+	test.InOrder = true
+	var got []vector
+	test.Run(func(tc vector) { got = append(got, tc) })
+	if len(got) != 3 {
+		t.Fatalf("expected 3 test cases, got %v", len(got))
+	}
+	if last := got[2]; last.L != 20 || last.Section != "ENCRYPT" || last.Len != 16 || string(last.Msg) != "\x19\x5a" || len(last.MD) != 20 {
+		t.Errorf("unexpected last test case %+v", last)
+	}
+	if got[0].Section != "" {
+		t.Errorf("expected the first test case to come before the section, got %q", got[0].Section)
+	}
+	if src := test.Results()[1].Source; src != "testdata/sha1.rsp:11" {
+		t.Errorf("expected test case 1 to be defined at testdata/sha1.rsp:11, got %v", src)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+package tbltest
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger sets a logger to be given a structured record for the start and end of each run of the table,
+// and of each test case, so runs can be followed in structured log pipelines. The start of a test case is
+// logged at the debug level, its end at the info level, or the error level if it failed.
+//
+//	test.WithLogger(slog.New(slog.NewJSONHandler(os.Stderr, nil))).Run(func(tc testcase) bool { ... })
+func (tc *Test) WithLogger(l *slog.Logger) *Test {
+	if l == nil {
+		tc.events = nil
+		return tc
+	}
+	tc.events = slogEvents{l: l}
+	return tc
+}
+
+// slogEvents logs the events of a run with a slog.Logger.
+type slogEvents struct {
+	l *slog.Logger
+}
+
+func (s slogEvents) runStarted(test string, cases int, seed int64) {
+	s.l.Info("tbltest run started", "test", test, "cases", cases, "seed", seed)
+}
+
+func (s slogEvents) caseStarted(test string, idx int, name string) {
+	s.l.Debug("tbltest case started", "test", test, "index", idx, "name", name)
+}
+
+func (s slogEvents) caseFinished(test string, r CaseResult) {
+	level := slog.LevelInfo
+	attrs := []any{"test", test, "index", r.Index, "name", r.Name, "status", string(r.Status), "duration", r.Duration}
+	if r.Status.failed() {
+		level = slog.LevelError
+		attrs = append(attrs, "error", errMessage(r.Err))
+	}
+	s.l.Log(context.Background(), level, "tbltest case finished", attrs...)
+}
+
+func (s slogEvents) runFinished(test string, r *Result) {
+	s.l.Info("tbltest run finished", "test", test, "ran", r.Ran, "passed", r.Passed, "failed", r.Failed,
+		"quarantined", r.Quarantined, "warned", r.Warned, "skipped", r.Skipped, "duration", r.Duration, "seed", r.Seed)
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+package tbltest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	test := tbltest.Cases(0, 1)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	test.WithLogger(logger).Run(func(tc int) bool { return tc == 0 })
+
+	var records []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var rec map[string]interface{}
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rec)
+	}
+	want := []string{
+		"tbltest run started", "tbltest case started", "tbltest case finished",
+		"tbltest case started", "tbltest case finished", "tbltest run finished",
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %v records, got %v", len(want), records)
+	}
+	for i, rec := range records {
+		if rec["msg"] != want[i] || rec["test"] != "TestWithLogger" {
+			t.Errorf("record %v: expected %q for TestWithLogger, got %v", i, want[i], rec)
+		}
+	}
+	if rec := records[4]; rec["level"] != "ERROR" || rec["status"] != "fail" || rec["index"] != 1.0 {
+		t.Errorf("expected test case 1 to be logged as failed, got %v", rec)
+	}
+}
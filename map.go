@@ -0,0 +1,46 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FromMap creates a table of the values of m, which must be a map of string keys to test cases, with each
+// test case named by its key. The test cases are indexed in the sorted order of the keys, and the table is run
+// in that order unless InOrder is cleared.
+//
+//	test := tbltest.FromMap(map[string]testcase{
+//		"empty input": {input: ""},
+//		"one word":    {input: "hello"},
+//	})
+func FromMap(m interface{}) *Test {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		panicf("FromMap should be given a map of string keys to test cases, was given %T", m)
+	}
+	keys := v.MapKeys()
+	sort.Sort(byString(keys))
+	testcases := make([]TestCase, len(keys))
+	for i, k := range keys {
+		testcases[i] = Named(v.MapIndex(k).Interface(), k.String())
+	}
+	tc := Test{InOrder: true}
+	if v.Type().Elem().Kind() != reflect.Interface {
+		tc.vType = v.Type().Elem()
+	}
+	if err := tc.addCases(callerFileLine(), testcases); err != nil {
+		panicf("%v", err)
+	}
+	return &tc
+}
+
+// byString sorts string values.
+type byString []reflect.Value
+
+func (s byString) Len() int           { return len(s) }
+func (s byString) Less(i, j int) bool { return s[i].String() < s[j].String() }
+func (s byString) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,35 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestFromMap(t *testing.T) {
+	test := tbltest.FromMap(map[string]int{
+		"c": 3,
+		"a": 1,
+		"b": 2,
+	})
+	var names []string
+	var values []int
+	test.Run(func(idx, tc int) {
+		names = append(names, test.Name(idx))
+		values = append(values, tc)
+	})
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected the test cases to run as %v, got %v", want, names)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(values, want) {
+		t.Errorf("expected the values %v, got %v", want, values)
+	}
+	if n := tbltest.FromMap(map[string]int{}).Run(func(int) {}); n != 0 {
+		t.Errorf("expected no test cases to run, got %v", n)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "reflect"
+
+// marked is a test case, along with the marks that were placed on it by functions such as Tagged.
+// The test case is unwrapped, and the marks applied, when it is added to a table.
+type marked struct {
+	tcase TestCase
+	marks []func(*entry)
+}
+
+// mark adds m to the marks of the test case.
+func mark(tcase TestCase, m func(*entry)) TestCase {
+	if g, ok := tcase.(group); ok {
+		// Mark each of the test cases of the group instead.
+		marked := make(group, len(g))
+		for i, tcase := range g {
+			marked[i] = mark(tcase, m)
+		}
+		return marked
+	}
+	if mk, ok := tcase.(marked); ok {
+		// Copy the marks, so test cases marked from the same base don't share them.
+		mk.marks = append(append([]func(*entry){}, mk.marks...), m)
+		return mk
+	}
+	return marked{tcase: tcase, marks: []func(*entry){m}}
+}
+
+// newEntry creates the entry for the test case, applying any marks placed on it.
+func newEntry(tcase TestCase) entry {
+	mk, ok := tcase.(marked)
+	if !ok {
+		return entry{value: reflect.ValueOf(tcase)}
+	}
+	e := entry{value: reflect.ValueOf(mk.tcase)}
+	for _, m := range mk.marks {
+		m(&e)
+	}
+	return e
+}
+
+// Named names the test case, as shown in failures and reports and matched by tblTest.ProfileCase; by default
+// test cases are named by their Name or Desc field, or their String method, if they have one, and otherwise by
+// their index. The test cases of Generate are named by their index, as they are only generated when they run.
+//
+//	test := tbltest.Cases(
+//		tbltest.Named(testcase{input: ""}, "empty input"),
+//	)
+func Named(tcase TestCase, name string) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.name = name
+	})
+}
+
+// DefinedAt records source, such as the file and line of a loaded file, as where the test case was defined,
+// instead of the line that added it to the table. It is meant for loaders of test cases from other formats.
+func DefinedAt(tcase TestCase, source string) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.source = source
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestNamed(t *testing.T) {
+	test := tbltest.Cases(
+		tbltest.Named(1, "one"),
+		tbltest.DefinedAt(tbltest.Named(2, "two"), "cases.txt:2"),
+		3,
+	)
+	for idx, want := range []string{"one", "two", "2"} {
+		if name := test.Name(idx); name != want {
+			t.Errorf("expected test case %v to be named %v, got %v", idx, want, name)
+		}
+	}
+	test.ContinueOnFailure = true
+	res := test.RunResult(func(tc int) bool { return false })
+	for _, cr := range res.Cases {
+		want := "marker_test.go:"
+		if cr.Index == 1 {
+			want = "cases.txt:2"
+		}
+		if !strings.HasPrefix(cr.Source, want) {
+			t.Errorf("expected test case %v to be defined at %v, got %v", cr.Index, want, cr.Source)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Axes holds the value of each axis, keyed by the name of the axis, for one combination of a matrix.
+type Axes map[string]interface{}
+
+type axis struct {
+	name   string
+	values []interface{}
+}
+
+// MatrixBuilder builds a table from the combinations of the values of a set of axes.
+type MatrixBuilder struct {
+	axes []axis
+}
+
+// Matrix returns a new, empty, MatrixBuilder.
+//
+//	test := tbltest.Matrix().
+//		Axis("os", "linux", "darwin", "windows").
+//		Axis("size", 0, 1, 1024).
+//		Build(func(m tbltest.Axes) testcase {
+//			return testcase{os: m["os"].(string), size: m["size"].(int)}
+//		})
+func Matrix() *MatrixBuilder {
+	return &MatrixBuilder{}
+}
+
+// Axis adds an axis with the given name and values to the matrix.
+func (mb *MatrixBuilder) Axis(name string, values ...interface{}) *MatrixBuilder {
+	for _, a := range mb.axes {
+		if a.name == name {
+			panicf("Axis %v was already added to the matrix.", name)
+		}
+	}
+	mb.axes = append(mb.axes, axis{name: name, values: values})
+	return mb
+}
+
+// Build creates a test case for every combination of the axes' values, using fn which must be of the form:
+//
+//	func(m tbltest.Axes) $testcase
+//
+// Each test case is named after its combination, e.g. "os=linux,size=1024".
+func (mb *MatrixBuilder) Build(fn interface{}) *Test {
+	var combos [][]int
+	if len(mb.axes) > 0 {
+		combos = [][]int{{}}
+	}
+	// Build up the cross product one axis at a time; the last axis varies fastest.
+	for _, a := range mb.axes {
+		var next [][]int
+		for _, combo := range combos {
+			for i := range a.values {
+				next = append(next, append(append([]int(nil), combo...), i))
+			}
+		}
+		combos = next
+	}
+	return mb.build(fn, combos)
+}
+
+// Pairwise is like Build, but only creates enough test cases to cover every pair of values of any
+// two axes, rather than every combination. For large configuration spaces this is a much smaller
+// table that still catches the faults caused by the interaction of two settings.
+//
+// The combinations are chosen with the in-parameter-order (IPOG) strategy, so the table is the same
+// for a given set of axes.
+func (mb *MatrixBuilder) Pairwise(fn interface{}) *Test {
+	if len(mb.axes) < 3 {
+		// With fewer than three axes the full product is the smallest covering.
+		return mb.Build(fn)
+	}
+	for _, a := range mb.axes {
+		if len(a.values) == 0 {
+			return mb.build(fn, nil)
+		}
+	}
+	const unset = -1
+	// Start with every combination of the first two axes.
+	var rows [][]int
+	for i := range mb.axes[0].values {
+		for j := range mb.axes[1].values {
+			row := make([]int, len(mb.axes))
+			for k := range row {
+				row[k] = unset
+			}
+			row[0], row[1] = i, j
+			rows = append(rows, row)
+		}
+	}
+	type pair struct{ axis, value, kvalue int }
+	for k := 2; k < len(mb.axes); k++ {
+		uncovered := make(map[pair]bool)
+		for j := 0; j < k; j++ {
+			for vj := range mb.axes[j].values {
+				for vk := range mb.axes[k].values {
+					uncovered[pair{j, vj, vk}] = true
+				}
+			}
+		}
+		// Horizontal growth: give each existing row the value of axis k that covers the most new pairs.
+		for _, row := range rows {
+			best, bestCount := 0, -1
+			for vk := range mb.axes[k].values {
+				count := 0
+				for j := 0; j < k; j++ {
+					if row[j] != unset && uncovered[pair{j, row[j], vk}] {
+						count++
+					}
+				}
+				if count > bestCount {
+					best, bestCount = vk, count
+				}
+			}
+			row[k] = best
+			for j := 0; j < k; j++ {
+				delete(uncovered, pair{j, row[j], best})
+			}
+		}
+		// Vertical growth: fill in don't care values, or add rows, for the pairs that are left.
+		for j := 0; j < k; j++ {
+			for vj := range mb.axes[j].values {
+				for vk := range mb.axes[k].values {
+					p := pair{j, vj, vk}
+					if !uncovered[p] {
+						continue
+					}
+					delete(uncovered, p)
+					filled := false
+					for _, row := range rows {
+						if row[k] == vk && row[j] == unset {
+							row[j] = vj
+							filled = true
+							break
+						}
+					}
+					if filled {
+						continue
+					}
+					row := make([]int, len(mb.axes))
+					for i := range row {
+						row[i] = unset
+					}
+					row[j], row[k] = vj, vk
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	for _, row := range rows {
+		for i := range row {
+			if row[i] == unset {
+				row[i] = 0
+			}
+		}
+	}
+	return mb.build(fn, rows)
+}
+
+// build creates a test case for each of the combinations, given as an index into each axis' values.
+func (mb *MatrixBuilder) build(fn interface{}, combos [][]int) *Test {
+	build := reflect.ValueOf(fn)
+	if build.Kind() != reflect.Func {
+		panicf("Was not provided a build function.")
+	}
+	fnType := build.Type()
+	if fnType.NumIn() != 1 || fnType.In(0) != reflect.TypeOf(Axes(nil)) || fnType.NumOut() != 1 {
+		panicf("Build function should be of the form func(m tbltest.Axes) $testcase, was given %v", fnType)
+	}
+	tc := Test{vType: fnType.Out(0)}
+	source := callerFileLine()
+	for _, combo := range combos {
+		m := make(Axes, len(combo))
+		names := make([]string, len(combo))
+		for i, vi := range combo {
+			a := mb.axes[i]
+			m[a.name] = a.values[vi]
+			names[i] = fmt.Sprintf("%v=%v", a.name, a.values[vi])
+		}
+		tc.cases = append(tc.cases, entry{
+			gen: func() reflect.Value {
+				return build.Call([]reflect.Value{reflect.ValueOf(m)})[0]
+			},
+			lazy:   true,
+			name:   strings.Join(names, ","),
+			source: source,
+		})
+	}
+	return &tc
+}
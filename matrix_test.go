@@ -0,0 +1,78 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestMatrix(t *testing.T) {
+	type testcase struct {
+		os   string
+		size int
+	}
+	test := tbltest.Matrix().
+		Axis("os", "linux", "darwin").
+		Axis("size", 0, 1, 1024).
+		Build(func(m tbltest.Axes) testcase {
+			return testcase{os: m["os"].(string), size: m["size"].(int)}
+		})
+	test.InOrder = true
+
+	expected := []string{
+		"os=linux,size=0", "os=linux,size=1", "os=linux,size=1024",
+		"os=darwin,size=0", "os=darwin,size=1", "os=darwin,size=1024",
+	}
+	seen := make(map[testcase]bool)
+	count := test.Run(func(idx int, tc testcase) {
+		if name := test.Name(idx); name != expected[idx] {
+			t.Errorf("for test %v: expected name %v, got %v", idx, expected[idx], name)
+		}
+		seen[tc] = true
+	})
+	if count != 6 || len(seen) != 6 {
+		t.Errorf("expected 6 distinct cases, ran %v with %v distinct", count, len(seen))
+	}
+
+	empty := tbltest.Matrix().Axis("os").Build(func(m tbltest.Axes) testcase { return testcase{} })
+	if count := empty.Run(func(tc testcase) {}); count != 0 {
+		t.Errorf("expected an empty axis to produce no cases, ran %v", count)
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	type testcase struct {
+		a, b, c, d int
+	}
+	values := []interface{}{0, 1, 2}
+	test := tbltest.Matrix().
+		Axis("a", values...).
+		Axis("b", values...).
+		Axis("c", values...).
+		Axis("d", values...).
+		Pairwise(func(m tbltest.Axes) testcase {
+			return testcase{a: m["a"].(int), b: m["b"].(int), c: m["c"].(int), d: m["d"].(int)}
+		})
+
+	type pair struct{ x, y, vx, vy int }
+	covered := make(map[pair]bool)
+	count := test.Run(func(tc testcase) {
+		vals := []int{tc.a, tc.b, tc.c, tc.d}
+		for x := range vals {
+			for y := x + 1; y < len(vals); y++ {
+				covered[pair{x, y, vals[x], vals[y]}] = true
+			}
+		}
+	})
+	// 6 pairs of axes, with 9 combinations of values each.
+	if len(covered) != 6*9 {
+		t.Errorf("expected every pair to be covered, only %v of %v were", len(covered), 6*9)
+	}
+	if count >= 81 {
+		t.Errorf("expected fewer cases than the full product, got %v", count)
+	}
+}
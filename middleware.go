@@ -0,0 +1,41 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+// CaseFunc runs a single test case, returning a non-nil error if it failed.
+type CaseFunc func(idx int, tc TestCase) error
+
+// Middleware wraps the running of each test case. It is given the next CaseFunc in the chain, and returns a
+// CaseFunc that may do work before or after calling next, change the test case passed on, retry it, or turn
+// its outcome into a different error. The innermost CaseFunc calls the test function, and returns an error if
+// the test function returned false.
+//
+//	timing := func(next tbltest.CaseFunc) tbltest.CaseFunc {
+//		return func(idx int, tc tbltest.TestCase) error {
+//			defer func(start time.Time) { log.Printf("case %v took %v", idx, time.Since(start)) }(time.Now())
+//			return next(idx, tc)
+//		}
+//	}
+//	test.Use(timing).Run(func(tc testcase) bool { ... })
+type Middleware func(next CaseFunc) CaseFunc
+
+// Use adds the middleware to the ones wrapping each test case. The first middleware added is the outermost.
+func (tc *Test) Use(mw ...Middleware) *Test {
+	for _, m := range mw {
+		if m == nil {
+			panicf("Use called with a nil Middleware.")
+		}
+	}
+	tc.middleware = append(tc.middleware, mw...)
+	return tc
+}
+
+// chain wraps fn in the middleware, so that the first middleware is the outermost.
+func chain(fn CaseFunc, mw []Middleware) CaseFunc {
+	for i := len(mw) - 1; i >= 0; i-- {
+		fn = mw[i](fn)
+	}
+	return fn
+}
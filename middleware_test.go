@@ -0,0 +1,64 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestUse(t *testing.T) {
+	var calls []string
+	trace := func(name string) tbltest.Middleware {
+		return func(next tbltest.CaseFunc) tbltest.CaseFunc {
+			return func(idx int, tc tbltest.TestCase) error {
+				calls = append(calls, name)
+				return next(idx, tc)
+			}
+		}
+	}
+	double := func(next tbltest.CaseFunc) tbltest.CaseFunc {
+		return func(idx int, tc tbltest.TestCase) error {
+			return next(idx, tc.(int)*2)
+		}
+	}
+	var got []int
+	test := tbltest.Cases(1, 2)
+	test.InOrder = true
+	test.Use(trace("outer"), trace("inner")).Use(double).Run(func(tc int) {
+		got = append(got, tc)
+	})
+	if fmt.Sprint(calls) != "[outer inner outer inner]" {
+		t.Errorf("unexpected middleware order: %v", calls)
+	}
+	if fmt.Sprint(got) != "[2 4]" {
+		t.Errorf("expected the test cases to be doubled, got %v", got)
+	}
+}
+
+func TestUseError(t *testing.T) {
+	errOdd := errors.New("odd")
+	test := tbltest.Cases(0, 1, 2)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	test.Use(func(next tbltest.CaseFunc) tbltest.CaseFunc {
+		return func(idx int, tc tbltest.TestCase) error {
+			if tc.(int)%2 == 1 {
+				return errOdd
+			}
+			return next(idx, tc)
+		}
+	})
+	res := test.RunResult(func(tc int) {})
+	if res.Passed != 2 || res.Failed != 1 {
+		t.Fatalf("expected 2 passed and 1 failed, got %v passed and %v failed", res.Passed, res.Failed)
+	}
+	if res.Cases[1].Err != errOdd {
+		t.Errorf("expected the middleware's error, got %v", res.Cases[1].Err)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Minimize reduces the failing test case at idx, by removing elements of it and running the test function
+// again, to the smallest variation of it that still fails; the minimized test case is logged and returned.
+// The test case may be a string, a slice or a map, or a struct, in which case each of its string, slice and
+// map fields is minimized in turn. Elements are removed with the delta debugging algorithm, first in large
+// chunks and then in smaller ones, so a large input is minimized in relatively few runs. A test case that
+// does not fail is returned as it is.
+//
+//	for _, cr := range test.RunResult(fn).Cases {
+//		if cr.Status == tbltest.StatusFail {
+//			test.Minimize(cr.Index, fn)
+//		}
+//	}
+//
+// Unlike Shrink, which tries the variations returned by a function, Minimize needs no help to simplify a
+// test case, but only ever removes parts of it.
+func (tc *Test) Minimize(idx int, function TestFunc) TestCase {
+	if idx < 0 || idx >= len(tc.cases) {
+		panicf("Minimize called with the index %v, the table has %v test cases.", idx, len(tc.cases))
+	}
+	rn := tc.newRunner(function)
+	runs := 0
+	fails := func(v reflect.Value) bool {
+		runs++
+		return rn.call(idx, v.Interface()) != nil
+	}
+	v := tc.cases[idx].get()
+	if !fails(v) {
+		return v.Interface()
+	}
+	s := v
+	if s.Kind() == reflect.Ptr && !s.IsNil() {
+		s = s.Elem()
+	}
+	if s.Kind() != reflect.Struct {
+		v = minimizeValue(v, fails)
+	} else {
+		for i := 0; i < s.NumField(); i++ {
+			i := i
+			// with returns a copy of the test case, with field i set to f.
+			with := func(f reflect.Value) reflect.Value {
+				c := copyCase(v)
+				settableField(c, i).Set(f)
+				return c
+			}
+			v = with(minimizeValue(settableField(copyCase(v), i), func(f reflect.Value) bool { return fails(with(f)) }))
+		}
+	}
+	logf("Test case %v failed; minimized in %v runs to: %#v", idx, runs, v.Interface())
+	return v.Interface()
+}
+
+// minimizeValue returns the smallest part of the string, slice or map v found for which fails is true, given it
+// is true for v. Other values are returned as they are.
+func minimizeValue(v reflect.Value, fails func(reflect.Value) bool) reflect.Value {
+	var keys []reflect.Value
+	switch v.Kind() {
+	case reflect.String, reflect.Slice:
+	case reflect.Map:
+		keys = v.MapKeys()
+		sort.Sort(byFormatted(keys))
+	default:
+		return v
+	}
+	// keep returns v with only the elements at the given indexes.
+	keep := func(idxs []int) reflect.Value {
+		switch v.Kind() {
+		case reflect.String:
+			b := make([]byte, len(idxs))
+			for i, j := range idxs {
+				b[i] = v.String()[j]
+			}
+			return reflect.ValueOf(string(b)).Convert(v.Type())
+		case reflect.Slice:
+			c := reflect.MakeSlice(v.Type(), len(idxs), len(idxs))
+			for i, j := range idxs {
+				c.Index(i).Set(v.Index(j))
+			}
+			return c
+		}
+		c := reflect.MakeMap(v.Type())
+		for _, j := range idxs {
+			c.SetMapIndex(keys[j], v.MapIndex(keys[j]))
+		}
+		return c
+	}
+	current := seq(v.Len())
+	n := 2
+	for steps := 0; len(current) > 0 && steps < maxShrinkSteps; {
+		if n > len(current) {
+			n = len(current)
+		}
+		chunk := (len(current) + n - 1) / n
+		reduced := false
+		for start := 0; start < len(current) && steps < maxShrinkSteps; start += chunk {
+			end := start + chunk
+			if end > len(current) {
+				end = len(current)
+			}
+			// Try the complement of the chunk.
+			candidate := append(append([]int{}, current[:start]...), current[end:]...)
+			steps++
+			if fails(keep(candidate)) {
+				current = candidate
+				if n > 2 {
+					n--
+				}
+				reduced = true
+				break
+			}
+		}
+		if !reduced {
+			if n >= len(current) {
+				break
+			}
+			n *= 2
+		}
+	}
+	return keep(current)
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestMinimize(t *testing.T) {
+	// The test function fails for any slice holding both a 3 and a 7.
+	hasBoth := func(s []int) bool {
+		var three, seven bool
+		for _, n := range s {
+			three = three || n == 3
+			seven = seven || n == 7
+		}
+		return three && seven
+	}
+	test := tbltest.Cases([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int{1})
+	fn := func(tc []int) bool { return !hasBoth(tc) }
+	if got := test.Minimize(0, fn).([]int); !reflect.DeepEqual(got, []int{3, 7}) {
+		t.Errorf("expected the test case to be minimized to [3 7], got %v", got)
+	}
+	if got := test.Minimize(1, fn).([]int); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("expected a passing test case to be returned as it is, got %v", got)
+	}
+
+	type testcase struct {
+		input string
+		opts  map[string]bool
+		id    int
+	}
+	structs := tbltest.Cases(testcase{input: "hello <b>world</b>!", opts: map[string]bool{"a": true, "strict": true, "z": false}, id: 4})
+	got := structs.Minimize(0, func(tc testcase) bool {
+		return !(strings.Contains(tc.input, "<b") && tc.opts["strict"])
+	}).(testcase)
+	if want := (testcase{input: "<b", opts: map[string]bool{"strict": true}, id: 4}); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the fields to be minimized to %+v, got %+v", want, got)
+	}
+}
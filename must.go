@@ -0,0 +1,50 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "testing"
+
+// MustRun is like Run, but fails t unless all the test cases selected to run were run and passed, rather than
+// leaving the count it returns to be checked. So t fails if a test case failed, or if the run stopped before
+// it got to all the test cases, such as after a failure without ContinueOnFailure, or when the
+// tblTest.MaxDuration budget ran out. The test cases left out of the run on purpose, by options such as
+// tblTest.Tags or tblTest.Shard, or by WithSkip, are only logged. The failures of quarantined test cases, and of
+// those only warned of (see Rated), do not fail t.
+//
+//	test.MustRun(t, func(tc testcase) bool { ... })
+func (tc *Test) MustRun(t testing.TB, function TestFunc) int {
+	t.Helper()
+	if function == nil {
+		t.Fatalf("MustRun called with a nil function.")
+		return 0
+	}
+	r := tc.RunResult(function)
+	var failed []int
+	ran := make(map[int]bool, len(r.Cases))
+	for _, cr := range r.Cases {
+		ran[cr.Index] = true
+		if cr.Status == StatusFail {
+			failed = append(failed, cr.Index)
+		}
+	}
+	if len(failed) > 0 {
+		t.Errorf("%v of the %v test cases run failed: %v", len(failed), r.Ran, summarizeIndexes(failed))
+	}
+	var notRun []int
+	selected := make(map[int]bool, len(r.Order))
+	for _, idx := range r.Order {
+		if !ran[idx] && !selected[idx] {
+			notRun = append(notRun, idx)
+		}
+		selected[idx] = true
+	}
+	if len(notRun) > 0 {
+		t.Errorf("%v of the %v test cases selected to run were not run: %v", len(notRun), len(selected), summarizeIndexes(notRun))
+	}
+	if left := len(tc.cases) - len(selected); left > 0 && len(r.Order) > 0 {
+		t.Logf("%v of the %v test cases of the table were not selected to run.", left, len(tc.cases))
+	}
+	return r.Ran
+}
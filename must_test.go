@@ -0,0 +1,47 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+// recordingT records the errors it is given, instead of failing the test.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper()                                 {}
+func (r *recordingT) Logf(format string, args ...interface{}) {}
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMustRun(t *testing.T) {
+	for _, tt := range []struct {
+		cont bool
+		want []string
+	}{
+		{true, []string{"1 of the 4 test cases run failed: 1"}},
+		{false, []string{"1 of the 2 test cases run failed: 1", "2 of the 4 test cases selected to run were not run: 2, 3"}},
+	} {
+		test := tbltest.Cases(0, 1, 2, 3)
+		test.InOrder = true
+		test.ContinueOnFailure = tt.cont
+		rt := &recordingT{TB: t}
+		test.MustRun(rt, func(tc int) bool { return tc != 1 })
+		if !reflect.DeepEqual(rt.errors, tt.want) {
+			t.Errorf("with ContinueOnFailure %v, expected the errors %q, got %q", tt.cont, tt.want, rt.errors)
+		}
+	}
+	if n := tbltest.Cases(0, 1).MustRun(t, func(tc int) {}); n != 2 {
+		t.Errorf("expected 2 test cases to run, got %v", n)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// Mutator derives corrupted variants of a test case, for Mutate. The variants must be of the same type as the
+// test case.
+type Mutator func(tcase TestCase) []TestCase
+
+// Mutate returns a table of the variants of each test case derived by the mutators, to check that the code
+// under test rejects corrupted input gracefully; the test cases that are known to be valid stand in for the
+// invalid ones that are not written by hand. Each variant is named after its test case, as in 3/mutant2, and
+// the test function of the table should return whether the variant was rejected. A test function that panics
+// fails the variant, rather than the whole test.
+//
+//	test.Mutate(tbltest.TruncateStrings, tbltest.FlipBytes).Run(func(tc testcase) bool {
+//		_, err := Parse(tc.input)
+//		return err != nil
+//	})
+//
+// Besides the mutators of this package, any function of the Mutator form can be used; such as one that knows
+// which fields must be set.
+func (tc *Test) Mutate(mutators ...Mutator) *Test {
+	mutants := Test{vType: tc.vType, middleware: []Middleware{recoverPanics}}
+	for idx := range tc.cases {
+		e := &tc.cases[idx]
+		n := 0
+		for _, m := range mutators {
+			for _, tcase := range m(e.get().Interface()) {
+				v := reflect.ValueOf(tcase)
+				if !v.IsValid() || v.Type() != tc.vType {
+					panicf("The Mutator returned a variant of type %T, expected it to be %v", tcase, tc.vType)
+				}
+				mutants.cases = append(mutants.cases, entry{
+					value:  v,
+					name:   fmt.Sprintf("%v/mutant%v", e.caseName(idx), n),
+					tags:   e.tags,
+					source: e.source,
+				})
+				n++
+			}
+		}
+	}
+	return &mutants
+}
+
+// recoverPanics is a Middleware that fails the test cases whose test function panics, instead of panicking.
+func recoverPanics(next CaseFunc) CaseFunc {
+	return func(idx int, tc TestCase) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = fmt.Errorf("panicked: %v", rec)
+			}
+		}()
+		return next(idx, tc)
+	}
+}
+
+// TruncateStrings is a Mutator that derives a variant for each non-empty string, or []byte, field of the test
+// case with the field cut to half of its length, and another with its last byte cut off.
+func TruncateStrings(tcase TestCase) []TestCase {
+	return fieldMutants(tcase, func(f reflect.Value) []func(reflect.Value) {
+		if !isBytes(f) || f.Len() == 0 {
+			return nil
+		}
+		n := f.Len()
+		lengths := []int{n / 2}
+		if n-1 != n/2 {
+			lengths = append(lengths, n-1)
+		}
+		var setters []func(reflect.Value)
+		for _, l := range lengths {
+			l := l
+			setters = append(setters, func(f reflect.Value) { f.Set(f.Slice(0, l)) })
+		}
+		return setters
+	})
+}
+
+// FlipBytes is a Mutator that derives variants of each non-empty string, or []byte, field of the test case
+// with the bits of its first, middle or last byte flipped.
+func FlipBytes(tcase TestCase) []TestCase {
+	return fieldMutants(tcase, func(f reflect.Value) []func(reflect.Value) {
+		if !isBytes(f) || f.Len() == 0 {
+			return nil
+		}
+		n := f.Len()
+		var setters []func(reflect.Value)
+		seen := make(map[int]bool)
+		for _, i := range []int{0, n / 2, n - 1} {
+			if seen[i] {
+				continue
+			}
+			seen[i] = true
+			i := i
+			setters = append(setters, func(f reflect.Value) {
+				b := []byte(f.String())
+				if f.Kind() == reflect.Slice {
+					b = append([]byte(nil), f.Bytes()...)
+				}
+				b[i] ^= 0xff
+				if f.Kind() == reflect.Slice {
+					f.SetBytes(b)
+				} else {
+					f.SetString(string(b))
+				}
+			})
+		}
+		return setters
+	})
+}
+
+// ZeroFields is a Mutator that derives a variant for each field of the test case that is set, with the field
+// set to its zero value, as if it had been forgotten.
+func ZeroFields(tcase TestCase) []TestCase {
+	return fieldMutants(tcase, func(f reflect.Value) []func(reflect.Value) {
+		if isZero(f) {
+			return nil
+		}
+		return []func(reflect.Value){func(f reflect.Value) { f.Set(reflect.Zero(f.Type())) }}
+	})
+}
+
+// isBytes reports whether v is a string or a []byte.
+func isBytes(v reflect.Value) bool {
+	return v.Kind() == reflect.String || v.Type() == bytesType
+}
+
+// fieldMutants returns a variant of tcase for each of the setters returned by mutate for the fields of tcase,
+// or for tcase itself if it is not a struct, or a pointer to one. Each variant is a deep copy of tcase, with
+// one setter applied to its field.
+func fieldMutants(tcase TestCase, mutate func(f reflect.Value) []func(reflect.Value)) []TestCase {
+	v := reflect.ValueOf(tcase)
+	if !v.IsValid() {
+		return nil
+	}
+	// target returns the value in c, a copy of tcase, that the setters of field i change.
+	target := func(c reflect.Value, i int) reflect.Value {
+		if i < 0 {
+			if c.Kind() == reflect.Ptr {
+				return c.Elem()
+			}
+			return c
+		}
+		return settableField(c, i)
+	}
+	fields := []int{-1}
+	s := v
+	if s.Kind() == reflect.Ptr && !s.IsNil() {
+		s = s.Elem()
+	}
+	if s.Kind() == reflect.Struct {
+		fields = fields[:0]
+		for i := 0; i < s.NumField(); i++ {
+			fields = append(fields, i)
+		}
+	} else if s.Kind() == reflect.Ptr {
+		return nil
+	}
+	var mutants []TestCase
+	for _, i := range fields {
+		setters := mutate(target(copyCase(v), i))
+		for _, set := range setters {
+			c := copyCase(v)
+			set(target(c, i))
+			mutants = append(mutants, c.Interface())
+		}
+	}
+	return mutants
+}
+
+// settableField returns field i of the struct v, or of the struct v points to, such that it can be set even
+// if it is unexported. v must be addressable, or a pointer.
+func settableField(v reflect.Value, i int) reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	// Go through the field's address, so unexported fields can be set too.
+	f := v.Field(i)
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+// copyCase returns a settable deep copy of the test case v.
+func copyCase(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(deepCopy(v, make(map[copyKey]reflect.Value)))
+	return c
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestMutate(t *testing.T) {
+	type message struct {
+		kind    string
+		payload []byte
+		size    int
+	}
+	test := tbltest.Cases(tbltest.Named(message{kind: "ping", payload: []byte{1, 2, 3}, size: 3}, "ping"))
+
+	truncated := test.Mutate(tbltest.TruncateStrings)
+	var got []message
+	for i := 0; i < truncated.Len(); i++ {
+		got = append(got, truncated.Case(i).(message))
+	}
+	want := []message{
+		{kind: "pi", payload: []byte{1, 2, 3}, size: 3},
+		{kind: "pin", payload: []byte{1, 2, 3}, size: 3},
+		{kind: "ping", payload: []byte{1}, size: 3},
+		{kind: "ping", payload: []byte{1, 2}, size: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the truncated variants %+v, got %+v", want, got)
+	}
+	if name := truncated.Name(1); name != "ping/mutant1" {
+		t.Errorf("expected the second variant to be named ping/mutant1, got %v", name)
+	}
+	if tc := test.Case(0).(message); tc.kind != "ping" || tc.payload[0] != 1 {
+		t.Errorf("expected the table to be left as it was, got %+v", tc)
+	}
+
+	flipped := test.Mutate(tbltest.FlipBytes)
+	if n := flipped.Len(); n != 6 {
+		t.Errorf("expected 6 variants with flipped bytes, got %v", n)
+	}
+	if tc := flipped.Case(5).(message); tc.payload[2] != 3^0xff {
+		t.Errorf("expected the last byte of the payload to be flipped, got %+v", tc)
+	}
+	if n := test.Mutate(tbltest.ZeroFields).Len(); n != 3 {
+		t.Errorf("expected a variant for each of the 3 fields set, got %v", n)
+	}
+
+	// A validator that forgets to check the size panics, and fails the variant rather than the test.
+	mutants := test.Mutate(tbltest.TruncateStrings, tbltest.ZeroFields)
+	mutants.ContinueOnFailure = true
+	res := mutants.RunResult(func(tc message) bool {
+		if tc.kind == "" {
+			return true
+		}
+		return tc.payload[tc.size-1] == 0
+	})
+	if res.Failed == 0 {
+		t.Fatalf("expected the variants that are not rejected to fail")
+	}
+	var panicked bool
+	for _, cr := range res.Cases {
+		panicked = panicked || (cr.Err != nil && strings.HasPrefix(cr.Err.Error(), "panicked: "))
+	}
+	if !panicked {
+		t.Errorf("expected a variant to fail with a panic, got %+v", res.Cases)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"strings"
 )
 
 // MyCallerFileLine returns the FileLine of the caller of the function that called it :)
@@ -29,3 +30,80 @@ func MyCallerFileLine() string {
 	filename = filepath.Base(filename)
 	return fmt.Sprintf("%v:%v", filename, line)
 }
+
+// callerTestName returns the name of the test function, e.g. TestFoo, that the current goroutine is running
+// in. If there is no test function on the stack, it returns the file and line of the first caller outside of
+// this package instead.
+func callerTestName() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var outside string
+	for {
+		frame, more := frames.Next()
+		// Function names look like github.com/gdey/tbltest_test.TestFoo.func1
+		name := frame.Function
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+		parts := strings.Split(name, ".")
+		if len(parts) > 1 && strings.HasPrefix(parts[1], "Test") && strings.HasSuffix(frame.File, "_test.go") {
+			return parts[1]
+		}
+		if outside == "" && funcPackage(frame.Function) != "tbltest" {
+			outside = fmt.Sprintf("%v:%v", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	if outside == "" {
+		return "n/a"
+	}
+	return outside
+}
+
+// callerFileLine returns the file and line of the first caller outside of this package, such as the line of
+// a test that called Cases.
+func callerFileLine() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if pkg := funcPackage(frame.Function); pkg != "tbltest" && pkg != "runtime" {
+			return fmt.Sprintf("%v:%v", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "n/a"
+		}
+	}
+}
+
+// outsideCaller returns the name of the first function on the stack that is not part of this package.
+func outsideCaller() (name string, ok bool) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if pkg := funcPackage(frame.Function); pkg != "tbltest" && pkg != "runtime" {
+			return frame.Function, true
+		}
+		if !more {
+			return "", false
+		}
+	}
+}
+
+// funcPackage returns the (last element of the) package name of a function name, such as
+// github.com/gdey/tbltest.(*Test).Run.
+func funcPackage(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
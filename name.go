@@ -0,0 +1,44 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// nameFields are the fields of a test case struct that name it, in the order they are looked for. The case of
+// the first letter does not matter.
+var nameFields = []string{"Name", "Desc"}
+
+// derivedName returns the name of a test case that was not given one with Named: the first of its string fields
+// called Name or Desc that is set, or else what its String method returns, if it is a fmt.Stringer. Otherwise it
+// returns the empty string.
+func derivedName(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	s := v
+	for s.Kind() == reflect.Ptr && !s.IsNil() {
+		s = s.Elem()
+	}
+	if s.Kind() == reflect.Struct {
+		for _, field := range nameFields {
+			for _, name := range []string{field, strings.ToLower(field[:1]) + field[1:]} {
+				if f := s.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+					return f.String()
+				}
+			}
+		}
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() || !v.CanInterface() {
+		return ""
+	}
+	if str, ok := v.Interface().(fmt.Stringer); ok {
+		return str.String()
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+type stringerCase struct {
+	input string
+}
+
+func (c stringerCase) String() string { return "input " + c.input }
+
+func TestDerivedNames(t *testing.T) {
+	type named struct {
+		name  string
+		input string
+	}
+	type described struct {
+		Desc  string
+		input string
+	}
+	for _, tt := range []struct {
+		test *tbltest.Test
+		want []string
+	}{
+		{tbltest.Cases(named{name: "empty"}, named{input: "x"}), []string{"empty", "1"}},
+		{tbltest.Cases(&described{Desc: "pointer"}, (*described)(nil)), []string{"pointer", "1"}},
+		{tbltest.Cases(stringerCase{"a"}, tbltest.Named(stringerCase{"b"}, "explicit")), []string{"input a", "explicit"}},
+		{tbltest.Cases(time.Second), []string{"1s"}},
+	} {
+		for i, want := range tt.want {
+			if got := tt.test.Name(i); got != want {
+				t.Errorf("expected test case %v to be named %q, got %q", i, want, got)
+			}
+		}
+	}
+}
+
+func TestGeneratedNames(t *testing.T) {
+	type named struct {
+		Name string
+	}
+	test := tbltest.Generate(2, func(idx int) named { return named{Name: "caseA"} })
+	test.InOrder = true
+	before := []string{test.SubtestName(0), test.SubtestName(1)}
+	var ran []string
+	test.RunT(t, func(t *testing.T, tc named) {
+		ran = append(ran, t.Name())
+	})
+	if len(ran) != 2 {
+		t.Fatalf("expected both test cases to run, got %v", ran)
+	}
+	after := []string{test.SubtestName(0), test.SubtestName(1)}
+	for i, want := range []string{"0", "1"} {
+		if before[i] != want || after[i] != want || ran[i] != t.Name()+"/"+want {
+			t.Errorf("expected generated test case %v to be named %q before, during and after the run, got %q, %q and %q", i, want, before[i], ran[i], after[i])
+		}
+	}
+}
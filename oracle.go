@@ -0,0 +1,57 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// checkOracle checks that the Oracle is of the form func(tc $testcase, got $result) error, and that the test
+// function returns its $result; it returns whether the test function takes the index of the test case.
+func (tc *Test) checkOracle(fn reflect.Value) (oracle reflect.Value, twoInParams bool, err error) {
+	oracle = reflect.ValueOf(tc.Oracle)
+	if oracle.Kind() != reflect.Func {
+		return oracle, false, errors.New("Oracle was not provided a function.")
+	}
+	oType := oracle.Type()
+	if oType.NumIn() != 2 || oType.In(0) != tc.vType || oType.NumOut() != 1 || oType.Out(0) != errorType {
+		return oracle, false, fmt.Errorf("Oracle function should be of the form func(tc %v, got $result) error, was given %v", tc.vType, oType)
+	}
+	if fn.Kind() != reflect.Func {
+		return oracle, false, errors.New("Was not provided a function.")
+	}
+	fnType := fn.Type()
+	switch {
+	case fnType.NumIn() == 1 && fnType.In(0) == tc.vType:
+	case fnType.NumIn() == 2 && fnType.In(0) == reflect.TypeOf(0) && fnType.In(1) == tc.vType:
+		twoInParams = true
+	default:
+		return oracle, false, fmt.Errorf("With an Oracle, the test function should be of the form func(tc %v) %v or func(idx int, tc %[1]v) %[2]v, was given %v", tc.vType, oType.In(1), fnType)
+	}
+	if fnType.NumOut() != 1 || fnType.Out(0) != oType.In(1) {
+		return oracle, false, fmt.Errorf("With an Oracle, the test function should return a %v, as the Oracle takes, was given %v", oType.In(1), fnType)
+	}
+	return oracle, twoInParams, nil
+}
+
+// newOracleRunner returns a runner that checks the result of the test function for each test case with the
+// Oracle.
+func (tc *Test) newOracleRunner(fn reflect.Value) *runner {
+	oracle, twoInParams, err := tc.checkOracle(fn)
+	if err != nil {
+		panicf("%v", err)
+	}
+	return tc.newCheckRunner(func(idx int, tcase TestCase) error {
+		in := []reflect.Value{reflect.ValueOf(tcase)}
+		if twoInParams {
+			in = []reflect.Value{reflect.ValueOf(idx), in[0]}
+		}
+		got := fn.Call(in)[0]
+		err, _ := oracle.Call([]reflect.Value{reflect.ValueOf(tcase), got})[0].Interface().(error)
+		return err
+	})
+}
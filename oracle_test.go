@@ -0,0 +1,49 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestOracle(t *testing.T) {
+	test := tbltest.Cases("", "hello", "\x00\xff")
+	test.ContinueOnFailure = true
+	test.Oracle = func(tc string, got string) error {
+		back, err := hex.DecodeString(got)
+		if err != nil || string(back) != tc {
+			return fmt.Errorf("%q does not decode back to %q", got, tc)
+		}
+		return nil
+	}
+	if count := test.Run(func(tc string) string { return hex.EncodeToString([]byte(tc)) }); count != 3 {
+		t.Errorf("expected to run 3 tests, ran %v instead", count)
+	}
+	for _, cr := range test.Results() {
+		if cr.Err != nil {
+			t.Errorf("expected test case %v to pass, got %v", cr.Index, cr.Err)
+		}
+	}
+
+	test.Run(func(idx int, tc string) string { return strings.ToUpper(hex.EncodeToString([]byte(tc))) + "x" })
+	for _, cr := range test.Results() {
+		if cr.Err == nil || !strings.Contains(cr.Err.Error(), "does not decode back") {
+			t.Errorf("expected test case %v to fail the oracle, got %v", cr.Index, cr.Err)
+		}
+	}
+
+	if err := test.Validate(func(tc string) bool { return true }); err == nil {
+		t.Errorf("expected a test function that does not return the result of the oracle to be invalid")
+	}
+	test.Oracle = func(tc string) error { return nil }
+	if err := test.Validate(func(tc string) string { return tc }); err == nil {
+		t.Errorf("expected the oracle to be invalid")
+	}
+}
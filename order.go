@@ -0,0 +1,55 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+// Order is an order for the test cases of a table to run in.
+type Order int
+
+const (
+	// OrderRandom runs the test cases in a random order, shuffled with the tblTest.Seed option.
+	OrderRandom Order = iota
+	// OrderIndex runs the test cases in the order they were added; the same as setting InOrder.
+	OrderIndex
+	// OrderReverse runs the test cases in the reverse of the order they were added. Running a table both
+	// ways helps to flush out test cases that depend on the ones before them.
+	OrderReverse
+	// OrderByName runs the test cases sorted by name, which keeps the log of a run stable when test cases
+	// are added. Test cases without a name are named after their index, and so are sorted as strings.
+	OrderByName
+	// OrderFailedFirst runs the test cases that failed in the last run first, as recorded for the
+	// tblTest.RerunFailed option, and then the others; each in the order they were added. So a failure that
+	// has not been fixed yet is reported right away.
+	OrderFailedFirst
+)
+
+// String returns the name of the order.
+func (o Order) String() string {
+	switch o {
+	case OrderRandom:
+		return "random"
+	case OrderIndex:
+		return "index"
+	case OrderReverse:
+		return "reverse"
+	case OrderByName:
+		return "name"
+	case OrderFailedFirst:
+		return "failed-first"
+	}
+	return "unknown"
+}
+
+// casesByName sorts the indexes of the test cases by the names of the test cases.
+type casesByName struct {
+	idxs  []int
+	cases []entry
+}
+
+func (c casesByName) Len() int      { return len(c.idxs) }
+func (c casesByName) Swap(i, j int) { c.idxs[i], c.idxs[j] = c.idxs[j], c.idxs[i] }
+func (c casesByName) Less(i, j int) bool {
+	a, b := c.idxs[i], c.idxs[j]
+	return c.cases[a].caseName(a) < c.cases[b].caseName(b)
+}
@@ -0,0 +1,32 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestOrder(t *testing.T) {
+	var ran []int
+	test := tbltest.Cases(0, 1, 2, 3)
+	test.Order = tbltest.OrderReverse
+	test.Run(func(tc int) { ran = append(ran, tc) })
+	if fmt.Sprint(ran) != "[3 2 1 0]" {
+		t.Errorf("expected the test cases to run in reverse, got %v", ran)
+	}
+
+	var names []string
+	test = tbltest.Matrix().Axis("os", "linux", "darwin", "windows").Build(func(m tbltest.Axes) string {
+		return m["os"].(string)
+	})
+	test.Order = tbltest.OrderByName
+	test.Run(func(tc string) { names = append(names, tc) })
+	if fmt.Sprint(names) != "[darwin linux windows]" {
+		t.Errorf("expected the test cases to run sorted by name, got %v", names)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+)
+
+var profileCaseName = flagString("ProfileCase", "", "The name or index of a test case to write CPU and heap profiles of; see tblTest.ProfileOut.")
+
+var profileOut = flagString("ProfileOut", "", "The directory to write the profiles of tblTest.ProfileCase to. The default is the package directory.")
+
+// profiling wraps call so that the test case selected by the tblTest.ProfileCase option is run with the CPU
+// profiler, and a heap profile is written after it finishes. The profiles are written to files named after the
+// test function and the test case, such as TestFoo_slow_case.cpu.pprof, for go tool pprof.
+func (tc *Test) profiling(call CaseFunc) CaseFunc {
+	return func(idx int, tcase TestCase) error {
+		if idx < 0 || idx >= len(tc.cases) {
+			return call(idx, tcase)
+		}
+		name := tc.cases[idx].caseName(idx)
+		if *profileCaseName != name && *profileCaseName != strconv.Itoa(idx) {
+			return call(idx, tcase)
+		}
+		base := filepath.Join(*profileOut, profileFileName(callerTestName()+"_"+name))
+		if cpu, err := os.Create(base + ".cpu.pprof"); err != nil {
+			logf("Could not create the CPU profile of test case %v: %v", idx, err)
+		} else {
+			defer cpu.Close()
+			if err := pprof.StartCPUProfile(cpu); err != nil {
+				logf("Could not start the CPU profile of test case %v: %v", idx, err)
+			} else {
+				defer pprof.StopCPUProfile()
+			}
+		}
+		err := call(idx, tcase)
+		heap, herr := os.Create(base + ".heap.pprof")
+		if herr == nil {
+			// Get up to date statistics, as WriteHeapProfile reports the heap as of the last collection.
+			runtime.GC()
+			herr = pprof.WriteHeapProfile(heap)
+			if cerr := heap.Close(); herr == nil {
+				herr = cerr
+			}
+		}
+		if herr != nil {
+			logf("Could not write the heap profile of test case %v: %v", idx, herr)
+		} else {
+			logf("Wrote the profiles of test case %v (%v) to %v.*.pprof", idx, name, base)
+		}
+		return err
+	}
+}
+
+// profileFileName replaces the characters of name that may not be safe in a file name.
+func profileFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestProfileCase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	flag.Set("tblTest.ProfileCase", "2")
+	flag.Set("tblTest.ProfileOut", dir)
+	defer flag.Set("tblTest.ProfileCase", "")
+	defer flag.Set("tblTest.ProfileOut", "")
+
+	tbltest.Cases(0, 1, 2).Run(func(tc int) {})
+	files, err := filepath.Glob(filepath.Join(dir, "*.pprof"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"TestProfileCase_2.cpu.pprof", "TestProfileCase_2.heap.pprof"}
+	if len(files) != len(want) {
+		t.Fatalf("expected the profiles %v to be written, got %v", want, files)
+	}
+	for i, file := range files {
+		if filepath.Base(file) != want[i] {
+			t.Errorf("expected the profile %v, got %v", want[i], file)
+		}
+	}
+}
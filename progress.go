@@ -0,0 +1,90 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"sync"
+	"time"
+)
+
+var progress = flagDuration("Progress", 0, "If not zero, log the progress of long runs at this interval, e.g. 10s.")
+
+// progressReporter periodically logs how far along a run is. A nil progressReporter does nothing.
+type progressReporter struct {
+	mu       sync.Mutex
+	total    int
+	ran      int
+	failures int
+	running  bool
+	current  int
+	name     string
+
+	done chan struct{}
+}
+
+// startProgress starts logging the progress of a run of total test cases every interval. If interval is not
+// positive, it returns nil.
+func startProgress(interval time.Duration, total int) *progressReporter {
+	if interval <= 0 {
+		return nil
+	}
+	p := &progressReporter{total: total, done: make(chan struct{})}
+	go p.loop(interval)
+	return p
+}
+
+func (p *progressReporter) loop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.log()
+		}
+	}
+}
+
+func (p *progressReporter) log() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.running {
+		logf("ran %v/%v cases, %v failures, current: case %v (%v)", p.ran, p.total, p.failures, p.current, p.name)
+		return
+	}
+	logf("ran %v/%v cases, %v failures", p.ran, p.total, p.failures)
+}
+
+// start records that the test case at index idx is running.
+func (p *progressReporter) start(idx int, name string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.running, p.current, p.name = true, idx, name
+	p.mu.Unlock()
+}
+
+// finish records that the running test case is done.
+func (p *progressReporter) finish(passed bool) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.running = false
+	p.ran++
+	if !passed {
+		p.failures++
+	}
+	p.mu.Unlock()
+}
+
+func (p *progressReporter) stop() {
+	if p == nil {
+		return
+	}
+	close(p.done)
+}
@@ -0,0 +1,55 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+// syncBuffer is a bytes.Buffer that is safe to log to from several goroutines.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestProgress(t *testing.T) {
+	var buf syncBuffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	flag.Set("tblTest.Progress", "5ms")
+	defer flag.Set("tblTest.Progress", "0")
+
+	test := tbltest.Cases(0, 1)
+	test.InOrder = true
+	test.Run(func(tc int) {
+		if tc == 1 {
+			time.Sleep(50 * time.Millisecond)
+		}
+	})
+	if !strings.Contains(buf.String(), "ran 1/2 cases, 0 failures, current: case 1 (1)") {
+		t.Errorf("expected the progress to be logged, got log: %v", buf.String())
+	}
+}
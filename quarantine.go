@@ -0,0 +1,68 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "fmt"
+
+const (
+	quarantineReport = "report"
+	quarantineRun    = "run"
+	quarantineSkip   = "skip"
+)
+
+var quarantine = flagString("Quarantine", quarantineReport, "How to handle quarantined test cases: report (run them, but only report their failures), run (treat them like any other test case) or skip.")
+
+// Quarantine marks the test case as flaky, for the given reason. How quarantined test cases are handled is
+// controlled by the tblTest.Quarantine option:
+//
+//   - report, the default, runs the test case but a failure (the test function returning false, or panicking)
+//     is only logged, and does not stop the run. The quarantined failures are listed at the end of the run.
+//   - run treats the test case like any other.
+//   - skip does not run the test case.
+func Quarantine(tcase TestCase, reason string) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.quarantined = true
+		e.quarantineReason = reason
+	})
+}
+
+// quarantineMode returns the value of the tblTest.Quarantine option, defaulting to report for unknown values.
+func quarantineMode() string {
+	switch *quarantine {
+	case quarantineRun, quarantineSkip:
+		return *quarantine
+	case quarantineReport:
+	default:
+		logf("Unknown value %q for %v, using %q.", *quarantine, flagName("Quarantine"), quarantineReport)
+	}
+	return quarantineReport
+}
+
+// filterQuarantined removes the quarantined test cases from list.
+func (tc *Test) filterQuarantined(list []int) []int {
+	var filtered []int
+	for _, idx := range list {
+		if idx >= 0 && idx < len(tc.cases) && tc.cases[idx].quarantined {
+			continue
+		}
+		filtered = append(filtered, idx)
+	}
+	return filtered
+}
+
+// runQuarantined runs a quarantined test case. The returned error is not nil if the test case failed.
+func runQuarantined(call CaseFunc, idx int, e *entry) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			logf("Quarantined test case %v (%v) panicked: %v", idx, e.quarantineReason, rec)
+			err = fmt.Errorf("quarantined (%v): panicked: %v", e.quarantineReason, rec)
+		}
+	}()
+	if err := call(idx, e.get().Interface()); err != nil {
+		logf("Quarantined test case %v (%v) failed.", idx, e.quarantineReason)
+		return fmt.Errorf("quarantined (%v): %v", e.quarantineReason, err)
+	}
+	return nil
+}
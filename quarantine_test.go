@@ -0,0 +1,52 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestQuarantine(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	defer flag.Set("tblTest.Quarantine", "report")
+
+	test := tbltest.Cases(
+		0,
+		tbltest.Quarantine(1, "flaky network"),
+		2,
+	)
+	test.InOrder = true
+	fn := func(tc int) bool { return tc != 1 }
+
+	type testcase struct {
+		mode     string
+		expected int
+		logged   bool
+	}
+	tests := tbltest.Cases(
+		testcase{mode: "report", expected: 3, logged: true},
+		testcase{mode: "run", expected: 2},
+		testcase{mode: "skip", expected: 2},
+	)
+	tests.Run(func(tc testcase) {
+		buf.Reset()
+		flag.Set("tblTest.Quarantine", tc.mode)
+		if count := test.Run(fn); count != tc.expected {
+			t.Errorf("for %v: expected to run %v tests, ran %v", tc.mode, tc.expected, count)
+		}
+		if logged := strings.Contains(buf.String(), "flaky network"); logged != tc.logged {
+			t.Errorf("for %v: expected quarantined failure logged to be %v, got log: %v", tc.mode, tc.logged, buf.String())
+		}
+	})
+}
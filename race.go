@@ -0,0 +1,11 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build race
+// +build race
+
+package tbltest
+
+// raceEnabled is set if the race detector is enabled.
+const raceEnabled = true
@@ -0,0 +1,65 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+var seed = flagInt64("Seed", 0, "Seed to use for randomly generated test cases, and for the random run order. If zero, a seed based on the current time is used.")
+
+// newSeed returns the seed given by the tblTest.Seed option, or one based on the current time.
+func newSeed() int64 {
+	if *seed != 0 {
+		return *seed
+	}
+	return time.Now().UnixNano()
+}
+
+// Random creates a table of n randomly generated test cases. gen must be of the form:
+//
+//	func(r *rand.Rand) $testcase
+//
+// The seed used is logged, and the same cases can be generated again with the tblTest.Seed option.
+func Random(n int, gen interface{}) *Test {
+	tc := Test{}
+	s := tc.addRandom(n, gen)
+	logf("Generated %v random test cases with seed %v; use -%v=%[2]v to reproduce.", n, s, flagName("Seed"))
+	return &tc
+}
+
+// AddRandom adds n randomly generated test cases to the table. See Random.
+func (tc *Test) AddRandom(n int, gen interface{}) {
+	s := tc.addRandom(n, gen)
+	logf("Generated %v random test cases with seed %v; use -%v=%[2]v to reproduce.", n, s, flagName("Seed"))
+}
+
+// addRandom adds the generated cases and returns the seed that was used.
+func (tc *Test) addRandom(n int, gen interface{}) int64 {
+	fn := reflect.ValueOf(gen)
+	if fn.Kind() != reflect.Func {
+		panicf("Was not provided a generator function.")
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 1 || fnType.In(0) != reflect.TypeOf((*rand.Rand)(nil)) || fnType.NumOut() != 1 {
+		panicf("Generator function should be of the form func(r *rand.Rand) $testcase, was given %v", fnType)
+	}
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.vType == nil {
+		tc.vType = fnType.Out(0)
+	} else if fnType.Out(0) != tc.vType {
+		panicf("Testcases should be of type %v, but generator returns %v.", tc.vType, fnType.Out(0))
+	}
+	s := newSeed()
+	r := reflect.ValueOf(rand.New(rand.NewSource(s)))
+	source := callerFileLine()
+	for i := 0; i < n; i++ {
+		tc.cases = append(tc.cases, entry{value: fn.Call([]reflect.Value{r})[0], source: source, seed: s})
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRandom(t *testing.T) {
+	type testcase struct {
+		a, b int
+	}
+	gen := func(r *rand.Rand) testcase {
+		return testcase{a: r.Intn(100), b: r.Intn(100)}
+	}
+
+	flag.Set("tblTest.Seed", "42")
+	defer flag.Set("tblTest.Seed", "0")
+
+	collect := func(test *tbltest.Test) (cases []testcase) {
+		test.InOrder = true
+		test.Run(func(tc testcase) {
+			cases = append(cases, tc)
+		})
+		return cases
+	}
+	first := collect(tbltest.Random(20, gen))
+	test := tbltest.Cases(testcase{a: -1, b: -1})
+	test.AddRandom(20, gen)
+	second := collect(test)
+
+	if len(first) != 20 || len(second) != 21 {
+		t.Fatalf("expected 20 and 21 cases, got %v and %v", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i+1] {
+			t.Errorf("for test %v: expected the same seed to generate %v, got %v", i, first[i], second[i+1])
+		}
+	}
+}
+
+func TestWithRand(t *testing.T) {
+	order := func() (ran []int) {
+		tbltest.Cases(0, 1, 2, 3, 4, 5, 6, 7).WithRand(rand.New(rand.NewSource(7))).Run(func(tc int) {
+			ran = append(ran, tc)
+		})
+		return ran
+	}
+	first, second := order(), order()
+	if len(first) != 8 || fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Errorf("expected the same rand source to give the same order, got %v and %v", first, second)
+	}
+	if want := fmt.Sprint(rand.New(rand.NewSource(7)).Perm(8)); fmt.Sprint(first) != want {
+		t.Errorf("expected the order %v, got %v", want, first)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+var tableNames = flagString("Table", "", "Only run the tables registered under the given comma separated names (see Register); the other tables run no test cases.")
+
+// registry holds the tables added with Register, by name, and the names of the tables.
+var registry = struct {
+	sync.Mutex
+	tables map[string]*Test
+	names  map[*Test]string
+}{tables: make(map[string]*Test), names: make(map[*Test]string)}
+
+// RegisteredTable is a table added with Register.
+type RegisteredTable struct {
+	Name string
+	Test *Test
+}
+
+// Register adds the table to the ones returned by Registered, under the given name, and returns it. It lets
+// tooling, such as a TestMain that reports on the tables, or an editor plugin, find the tables a package
+// defines and run them. The name is given in the listings of the tblTest.List option, and the tblTest.Table
+// option runs only the tables with the given names; the tbl command uses both, in its tables command and with
+// the -table flag of its run command. Register panics if a table is already registered under the name.
+//
+//	var parseCases = tbltest.Register("parse", tbltest.Cases(
+//		...
+//	))
+func Register(name string, test *Test) *Test {
+	if test == nil {
+		panicf("Register called with a nil table for %q.", name)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.tables[name]; ok {
+		panicf("A table is already registered as %q.", name)
+	}
+	registry.tables[name] = test
+	registry.names[test] = name
+	return test
+}
+
+// registeredName returns the name the table was registered under, or the empty string.
+func (tc *Test) registeredName() string {
+	registry.Lock()
+	defer registry.Unlock()
+	return registry.names[tc]
+}
+
+// filterTable returns list, if the table is one of the tables selected by the tblTest.Table option, and nothing
+// otherwise.
+func (tc *Test) filterTable(list []int) []int {
+	name := tc.registeredName()
+	for _, selected := range strings.Split(*tableNames, ",") {
+		if name != "" && strings.TrimSpace(selected) == name {
+			return list
+		}
+	}
+	return nil
+}
+
+// Registered returns the tables added with Register, sorted by name.
+func Registered() []RegisteredTable {
+	registry.Lock()
+	defer registry.Unlock()
+	tables := make([]RegisteredTable, 0, len(registry.tables))
+	for name, test := range registry.tables {
+		tables = append(tables, RegisteredTable{Name: name, Test: test})
+	}
+	sort.Sort(byTableName(tables))
+	return tables
+}
+
+// byTableName sorts registered tables by name.
+type byTableName []RegisteredTable
+
+func (s byTableName) Len() int           { return len(s) }
+func (s byTableName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s byTableName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
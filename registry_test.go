@@ -0,0 +1,54 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+var registeredB = tbltest.Register("registry_test/b", tbltest.Cases(2, 3))
+var registeredA = tbltest.Register("registry_test/a", tbltest.Cases(1))
+
+func TestRegistered(t *testing.T) {
+	var names []string
+	for _, table := range tbltest.Registered() {
+		if table.Name == "registry_test/a" && table.Test != registeredA || table.Name == "registry_test/b" && table.Test != registeredB {
+			t.Errorf("unexpected table registered as %v", table.Name)
+		}
+		names = append(names, table.Name)
+	}
+	if len(names) < 2 {
+		t.Fatalf("expected the tables to be registered, got %v", names)
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Errorf("expected the tables to be sorted by name, got %v", names)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected registering a name twice to panic")
+		}
+	}()
+	tbltest.Register("registry_test/a", tbltest.Cases(1))
+}
+
+func TestTableFlag(t *testing.T) {
+	flag.Set("tblTest.Table", "registry_test/b, registry_test/c")
+	defer flag.Set("tblTest.Table", "")
+
+	var ran []int
+	for _, test := range []*tbltest.Test{registeredA, registeredB, tbltest.Cases(4)} {
+		test.InOrder = true
+		test.Run(func(tc int) { ran = append(ran, tc) })
+	}
+	if fmt.Sprint(ran) != "[2 3]" {
+		t.Errorf("expected only the test cases of the selected table to run, got %v", ran)
+	}
+}
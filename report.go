@@ -0,0 +1,103 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// suiteResult is the result of one run of a table, as collected for the reports.
+type suiteResult struct {
+	// name is the name of the test function the table was run from.
+	name string
+	// table identifies the table, as the test function and where its first test case was defined.
+	table   string
+	started time.Time
+	// seed is the seed used to shuffle the test cases, or zero if they were not shuffled.
+	seed int64
+	// order is the order the test cases were run in, as given to the runner.
+	order   []int
+	results []CaseResult
+}
+
+// reporter writes the collected results to the file named by its option.
+type reporter struct {
+	path  *string
+	write func(w io.Writer, suites []suiteResult) error
+}
+
+// reporters are the report formats that can be requested on the command line.
+var reporters []reporter
+
+// reports holds the results of every run so far, when a report was requested.
+var reports struct {
+	sync.Mutex
+	suites []suiteResult
+}
+
+// wantReports reports if any report was requested.
+func wantReports() bool {
+	for _, r := range reporters {
+		if *r.path != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// recordRun adds the results of a run to the reports, and rewrites each requested report. Every report holds
+// all of the runs in the test binary so far, so the last one written is complete.
+func recordRun(table string, results []CaseResult, seed int64, order []int) {
+	if !wantReports() {
+		return
+	}
+	suite := suiteResult{
+		name:    callerTestName(),
+		table:   table,
+		started: time.Now(),
+		seed:    seed,
+		order:   order,
+		results: results,
+	}
+	for _, r := range results {
+		suite.started = suite.started.Add(-r.Duration)
+	}
+
+	reports.Lock()
+	defer reports.Unlock()
+	reports.suites = append(reports.suites, suite)
+	for _, r := range reporters {
+		if *r.path == "" {
+			continue
+		}
+		suites := reports.suites
+		if *r.path == "-" {
+			// Anything already written to stdout can't be rewritten, so only write this run.
+			suites = suites[len(suites)-1:]
+		}
+		if err := writeReport(*r.path, r.write, suites); err != nil {
+			logf("Failed to write report %v: %v", *r.path, err)
+		}
+	}
+}
+
+// writeReport writes the report to the named file, or to stdout if the name is "-".
+func writeReport(path string, write func(w io.Writer, suites []suiteResult) error, suites []suiteResult) error {
+	if path == "-" {
+		return write(os.Stdout, suites)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, suites); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,149 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"html/template"
+	"io"
+	"time"
+)
+
+var htmlPath = flagString("HTML", "", "Write an HTML report summarizing the results of each run to the given file.")
+
+func init() {
+	reporters = append(reporters, reporter{path: htmlPath, write: writeHTML})
+}
+
+// htmlBuckets are the upper bounds of the buckets of the durations histogram.
+var htmlBuckets = []time.Duration{
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+type htmlReport struct {
+	Generated time.Time
+	Suites    []htmlSuite
+	Histogram []htmlBucket
+	Failures  []htmlFailure
+}
+
+type htmlSuite struct {
+	Name     string
+	Duration time.Duration
+	Counts   map[Status]int
+	Cases    []CaseResult
+}
+
+// Count returns the number of test cases with the given status.
+func (hs htmlSuite) Count(status string) int {
+	return hs.Counts[Status(status)]
+}
+
+type htmlBucket struct {
+	Label string
+	Count int
+	// Width is the width of the bar, as a percentage of the largest bucket.
+	Width int
+}
+
+type htmlFailure struct {
+	Suite   string
+	Case    CaseResult
+	Command string
+}
+
+// writeHTML writes a self contained HTML page with a summary of every run; the status of each test case, a
+// histogram of the durations, and the failures along with the command to reproduce each one.
+func writeHTML(w io.Writer, suites []suiteResult) error {
+	report := htmlReport{Generated: time.Now()}
+	counts := make([]int, len(htmlBuckets)+1)
+	for _, s := range suites {
+		hs := htmlSuite{Name: s.name, Counts: make(map[Status]int), Cases: s.results}
+		for _, r := range s.results {
+			hs.Duration += r.Duration
+			hs.Counts[r.Status]++
+			b := 0
+			for b < len(htmlBuckets) && r.Duration >= htmlBuckets[b] {
+				b++
+			}
+			counts[b]++
+			if r.Status.failed() {
+				report.Failures = append(report.Failures, htmlFailure{
+					Suite:   s.name,
+					Case:    r,
+					Command: r.Repro,
+				})
+			}
+		}
+		report.Suites = append(report.Suites, hs)
+	}
+	max := 0
+	for _, c := range counts {
+		if c > max {
+			max = c
+		}
+	}
+	for i, c := range counts {
+		var label string
+		if i < len(htmlBuckets) {
+			label = "< " + htmlBuckets[i].String()
+		} else {
+			label = ">= " + htmlBuckets[len(htmlBuckets)-1].String()
+		}
+		width := 0
+		if max > 0 {
+			width = c * 100 / max
+		}
+		report.Histogram = append(report.Histogram, htmlBucket{Label: label, Count: c, Width: width})
+	}
+	return htmlTemplate.Execute(w, report)
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>tbltest report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+td, th { padding: 0.2em 0.6em; text-align: left; }
+.cells span { display: inline-block; width: 0.9em; height: 0.9em; margin: 1px; }
+.pass { background: #3c3; } .fail { background: #d33; } .xfail { background: #99c; } .quarantined { background: #eb3; } .warn { background: #e93; }
+.bar { background: #69c; height: 0.9em; }
+pre { background: #f4f4f4; padding: 0.5em; }
+</style>
+</head>
+<body>
+<h1>tbltest report</h1>
+<p>Generated {{.Generated.Format "2006-01-02 15:04:05"}}</p>
+
+<h2>Runs</h2>
+<table>
+<tr><th>Test</th><th>Cases</th><th>Pass</th><th>Fail</th><th>XFail</th><th>Quarantined</th><th>Warn</th><th>Duration</th><th>Results</th></tr>
+{{range .Suites}}<tr>
+<td>{{.Name}}</td><td>{{len .Cases}}</td><td>{{.Count "pass"}}</td><td>{{.Count "fail"}}</td>
+<td>{{.Count "xfail"}}</td><td>{{.Count "quarantined"}}</td><td>{{.Count "warn"}}</td><td>{{.Duration}}</td>
+<td class="cells">{{range .Cases}}<span class="{{.Status}}" title="{{.Index}}: {{.Name}} ({{.Status}}, {{.Duration}})"></span>{{end}}</td>
+</tr>
+{{end}}</table>
+
+<h2>Durations</h2>
+<table>
+{{range .Histogram}}<tr><td>{{.Label}}</td><td>{{.Count}}</td><td style="width: 20em"><div class="bar" style="width: {{.Width}}%"></div></td></tr>
+{{end}}</table>
+
+<h2>Failures</h2>
+{{range .Failures}}<h3>{{.Suite}}: case {{.Case.Index}} ({{.Case.Name}})</h3>
+<pre>{{if .Case.Err}}{{.Case.Err.Error}}{{end}}</pre>
+<p>Reproduce with: <code>{{.Command}}</code></p>
+{{else}}<p>No failures.</p>
+{{end}}
+</body>
+</html>
+`))
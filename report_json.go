@@ -0,0 +1,92 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+var jsonPath = flagString("JSON", "", "Write the results of each test case as JSON to the given file, or - for stdout.")
+
+func init() {
+	reporters = append(reporters, reporter{path: jsonPath, write: writeJSON})
+}
+
+// JSONSchemaVersion is the version of the format written by the tblTest.JSON option. It is only changed when
+// a field is removed or changes meaning; fields may be added without changing it. The format is:
+//
+//	{
+//		"version": 1,
+//		"runs": [{
+//			"test": "TestFoo",            // name of the test function
+//			"table": "TestFoo@foo_test.go:12", // the test function, and where the first test case was defined
+//			"started": "2006-01-02T15:04:05Z07:00",
+//			"seed": 42,                   // seed of the random order, omitted if not shuffled
+//			"order": [2, 0, 1],           // the order the test cases were run in
+//			"cases": [{
+//				"index": 2,
+//				"name": "parse_empty_input",
+//				"status": "fail",         // pass, fail, xfail, quarantined or warn
+//				"duration_ns": 1200,
+//				"error": "test function returned false" // omitted if the test case did not fail
+//			}]
+//		}]
+//	}
+const JSONSchemaVersion = 1
+
+type jsonReport struct {
+	Version int       `json:"version"`
+	Runs    []jsonRun `json:"runs"`
+}
+
+type jsonRun struct {
+	Test    string     `json:"test"`
+	Table   string     `json:"table"`
+	Started time.Time  `json:"started"`
+	Seed    int64      `json:"seed,omitempty"`
+	Order   []int      `json:"order"`
+	Cases   []jsonCase `json:"cases"`
+}
+
+type jsonCase struct {
+	Index    int    `json:"index"`
+	Name     string `json:"name"`
+	Status   Status `json:"status"`
+	Duration int64  `json:"duration_ns"`
+	Error    string `json:"error,omitempty"`
+}
+
+// writeJSON writes the suites in the format described by JSONSchemaVersion.
+func writeJSON(w io.Writer, suites []suiteResult) error {
+	report := jsonReport{Version: JSONSchemaVersion, Runs: []jsonRun{}}
+	for _, s := range suites {
+		run := jsonRun{
+			Test:    s.name,
+			Table:   s.table,
+			Started: s.started,
+			Seed:    s.seed,
+			Order:   s.order,
+			Cases:   []jsonCase{},
+		}
+		if run.Order == nil {
+			run.Order = []int{}
+		}
+		for _, r := range s.results {
+			run.Cases = append(run.Cases, jsonCase{
+				Index:    r.Index,
+				Name:     r.Name,
+				Status:   r.Status,
+				Duration: int64(r.Duration),
+				Error:    errMessage(r.Err),
+			})
+		}
+		report.Runs = append(report.Runs, run)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(report)
+}
@@ -0,0 +1,95 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+var junitPath = flagString("JUnit", "", "Write the results of each test case as JUnit XML to the given file.")
+
+func init() {
+	reporters = append(reporters, reporter{path: junitPath, write: writeJUnit})
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      float64     `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// writeJUnit writes the suites in the JUnit XML format understood by most CI systems. Each run is a test suite
+// named after its test function, and each test case is a test case named after the test case.
+func writeJUnit(w io.Writer, suites []suiteResult) error {
+	var doc junitSuites
+	for _, s := range suites {
+		js := junitSuite{
+			Name:      s.name,
+			Tests:     len(s.results),
+			Timestamp: s.started.Format("2006-01-02T15:04:05"),
+		}
+		for _, r := range s.results {
+			jc := junitCase{
+				Name:      r.Name,
+				ClassName: s.name,
+				Time:      r.Duration.Seconds(),
+			}
+			switch r.Status {
+			case StatusFail:
+				js.Failures++
+				jc.Failure = &junitMessage{Message: errMessage(r.Err)}
+			case StatusXFail:
+				js.Skipped++
+				jc.Skipped = &junitMessage{Message: "failed, as expected"}
+			case StatusQuarantined, StatusWarn:
+				js.Skipped++
+				jc.Skipped = &junitMessage{Message: errMessage(r.Err)}
+			}
+			js.Time += jc.Time
+			js.Cases = append(js.Cases, jc)
+		}
+		doc.Suites = append(doc.Suites, js)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// errMessage returns the message of err, or the empty string if it is nil.
+func errMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var tapPath = flagString("TAP", "", "Write the results of each test case in the Test Anything Protocol format to the given file, or - for stdout.")
+
+func init() {
+	reporters = append(reporters, reporter{path: tapPath, write: writeTAP})
+}
+
+// tapEscaper escapes the characters that have a meaning in a TAP description.
+var tapEscaper = strings.NewReplacer(`\`, `\\`, "#", `\#`, "\n", " ")
+
+// writeTAP writes the suites as a TAP version 13 stream. Each test case is a test point, and each run starts
+// with a comment naming its test function. Expected failures, and the failures of quarantined test cases, are
+// reported as TODO test points.
+func writeTAP(w io.Writer, suites []suiteResult) error {
+	bw := bufio.NewWriter(w)
+	total := 0
+	for _, s := range suites {
+		total += len(s.results)
+	}
+	fmt.Fprintf(bw, "TAP version 13\n1..%v\n", total)
+	n := 0
+	for _, s := range suites {
+		fmt.Fprintf(bw, "# %v\n", s.name)
+		for _, r := range s.results {
+			n++
+			desc := tapEscaper.Replace(r.Name)
+			switch r.Status {
+			case StatusPass:
+				fmt.Fprintf(bw, "ok %v - %v\n", n, desc)
+			case StatusXFail:
+				fmt.Fprintf(bw, "not ok %v - %v # TODO expected failure\n", n, desc)
+			case StatusQuarantined, StatusWarn:
+				fmt.Fprintf(bw, "not ok %v - %v # TODO %v\n", n, desc, tapEscaper.Replace(errMessage(r.Err)))
+			default:
+				fmt.Fprintf(bw, "not ok %v - %v\n", n, desc)
+				fmt.Fprintf(bw, "  ---\n  message: %q\n  duration_ms: %v\n  ...\n", errMessage(r.Err), r.Duration.Seconds()*1000)
+			}
+		}
+	}
+	return bw.Flush()
+}
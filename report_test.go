@@ -0,0 +1,167 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+// withReport sets the report option to a file in a temporary directory, runs fn, and returns the report.
+func withReport(t *testing.T, option string, fn func()) []byte {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report")
+	flag.Set(option, path)
+	defer flag.Set(option, "")
+
+	fn()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the report: %v", err)
+	}
+	return data
+}
+
+func TestJUnitReport(t *testing.T) {
+	data := withReport(t, "tblTest.JUnit", func() {
+		test := tbltest.Cases(0, tbltest.ExpectFail(1, "bug"), 2)
+		test.InOrder = true
+		test.Run(func(tc int) bool { return tc != 1 && tc != 2 })
+	})
+	var doc struct {
+		Suites []struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Cases    []struct {
+				Name    string `xml:"name,attr"`
+				Failure *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to parse the report: %v\n%s", err, data)
+	}
+	suite := doc.Suites[len(doc.Suites)-1]
+	if suite.Name != "TestJUnitReport" || suite.Tests != 3 || suite.Failures != 1 {
+		t.Fatalf("unexpected suite in report:\n%s", data)
+	}
+	if c := suite.Cases[2]; c.Name != "2" || c.Failure == nil || c.Failure.Message != "test function returned false" {
+		t.Errorf("expected case 2 to have failed, got:\n%s", data)
+	}
+}
+
+func TestTAPReport(t *testing.T) {
+	data := withReport(t, "tblTest.TAP", func() {
+		test := tbltest.Matrix().
+			Axis("input", "empty", "a#b", "long").
+			Build(func(m tbltest.Axes) string { return m["input"].(string) })
+		test.InOrder = true
+		test.Run(func(tc string) bool { return tc != "long" })
+	})
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if lines[0] != "TAP version 13" || !strings.HasPrefix(lines[1], "1..") {
+		t.Fatalf("expected a TAP header, got:\n%s", data)
+	}
+	// The report holds every run so far, so look at the end of it, ignoring the test point numbers.
+	number := regexp.MustCompile(`^(not )?ok \d+ `)
+	expected := []string{
+		"# TestTAPReport",
+		"ok # - input=empty",
+		`ok # - input=a\#b`,
+		"not ok # - input=long",
+		"  ---",
+		`  message: "test function returned false"`,
+	}
+	idx := -1
+	for i, line := range lines {
+		if line == expected[0] {
+			idx = i
+		}
+	}
+	if idx < 0 || len(lines) < idx+len(expected) {
+		t.Fatalf("expected the run in the report, got:\n%s", data)
+	}
+	for i, line := range expected {
+		if got := number.ReplaceAllString(lines[idx+i], "${1}ok # "); got != line {
+			t.Errorf("line %v: expected %q, got %q", i, line, got)
+		}
+	}
+}
+
+func TestJSONReport(t *testing.T) {
+	flag.Set("tblTest.Seed", "7")
+	defer flag.Set("tblTest.Seed", "0")
+	data := withReport(t, "tblTest.JSON", func() {
+		test := tbltest.Cases(0, 1, 2)
+		test.Run(func(tc int) bool { return tc != 1 })
+	})
+	var report struct {
+		Version int
+		Runs    []struct {
+			Test  string
+			Seed  int64
+			Order []int
+			Cases []struct {
+				Index  int
+				Status string
+				Error  string
+			}
+		}
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("failed to parse the report: %v\n%s", err, data)
+	}
+	if report.Version != tbltest.JSONSchemaVersion {
+		t.Errorf("expected version %v, got %v", tbltest.JSONSchemaVersion, report.Version)
+	}
+	run := report.Runs[len(report.Runs)-1]
+	if run.Test != "TestJSONReport" || run.Seed != 7 || len(run.Order) != 3 {
+		t.Fatalf("unexpected run in report:\n%s", data)
+	}
+	last := run.Cases[len(run.Cases)-1]
+	if last.Index != 1 || last.Status != "fail" || last.Error != "test function returned false" {
+		t.Errorf("expected the run to stop at the failing case 1, got:\n%s", data)
+	}
+}
+
+func TestHTMLReport(t *testing.T) {
+	flag.Set("tblTest.FailOn", "major")
+	defer flag.Set("tblTest.FailOn", "minor")
+	data := withReport(t, "tblTest.HTML", func() {
+		test := tbltest.Cases(0, 1, tbltest.Rated(2, tbltest.SeverityMinor))
+		test.InOrder = true
+		test.ContinueOnFailure = true
+		test.Run(func(tc int) bool { return tc == 0 })
+	})
+	for _, expected := range []string{
+		"<title>tbltest report</title>",
+		"<td>TestHTMLReport</td>",
+		"<th>Warn</th>",
+		`<span class="warn" title="2: 2 (warn, `,
+		"<h3>TestHTMLReport: case 1 (1)</h3>",
+		"test function returned false",
+		"go test -run &#39;^TestHTMLReport$&#39; -args -tblTest.RunOrder=1",
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("expected the report to contain %q, got:\n%s", expected, data)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reproCommand returns the command that runs only the test case at index idx, of the table run by the named
+// test function. If the test case was randomly generated, seed is the seed it was generated with.
+func reproCommand(test string, idx int, seed int64) string {
+	args := fmt.Sprintf("-%v=%v", flagName("RunOrder"), idx)
+	if seed != 0 {
+		args += fmt.Sprintf(" -%v=%v", flagName("Seed"), seed)
+	}
+	return goTestCommand(test, args)
+}
+
+// orderCommand returns the command that runs the table of the named test function, shuffled with the seed.
+func orderCommand(test string, seed int64) string {
+	return goTestCommand(test, fmt.Sprintf("-%v=%v", flagName("Seed"), seed))
+}
+
+func goTestCommand(test string, args string) string {
+	if !strings.HasPrefix(test, "Test") {
+		// Not run from a test function, so there's nothing to select with -run.
+		return "go test -args " + args
+	}
+	return fmt.Sprintf("go test -run '^%v$' -args %v", test, args)
+}
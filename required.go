@@ -0,0 +1,121 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// hasTagOption reports whether the tbl struct tag of the field has the option, as in `tbl:"required"`.
+func hasTagOption(f reflect.StructField, option string) bool {
+	for _, opt := range strings.Split(f.Tag.Get("tbl"), ",") {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateCases checks that every test case sets the fields of its struct tagged `tbl:"required"`, that is
+// that they are not the zero value of their type. The error lists each test case with the required fields it
+// is missing. Test cases that are pointers to structs are checked through the pointer. The test cases of
+// Generate and Matrix that have not been generated yet are skipped, so checking a table does not call their
+// generators.
+//
+//	type testcase struct {
+//		input string `tbl:"required"`
+//		want  int
+//	}
+//
+// Run calls ValidateCases before running any test case, and panics with the error; TryRun returns it.
+func (tc *Test) ValidateCases() error {
+	t := tc.vType
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var required []int
+	for i := 0; i < t.NumField(); i++ {
+		if hasTagOption(t.Field(i), "required") {
+			required = append(required, i)
+		}
+	}
+	if len(required) == 0 {
+		return nil
+	}
+	var msgs []string
+	for idx := range tc.cases {
+		e := &tc.cases[idx]
+		if e.gen != nil {
+			continue
+		}
+		v := e.value
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				msgs = append(msgs, fmt.Sprintf("test case %v (%v) is nil", idx, e.caseName(idx)))
+				continue
+			}
+			v = v.Elem()
+		}
+		var missing []string
+		for _, i := range required {
+			if isZero(v.Field(i)) {
+				missing = append(missing, t.Field(i).Name)
+			}
+		}
+		if len(missing) > 0 {
+			msg := fmt.Sprintf("test case %v (%v) is missing the required fields %v", idx, e.caseName(idx), strings.Join(missing, ", "))
+			if e.source != "" {
+				msg += ", defined at " + e.source
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+// isZero reports whether v is the zero value of its type. Unlike comparing against reflect.Zero, it works for
+// the values of unexported fields.
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() == 0
+	case reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return v.IsNil()
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !isZero(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !isZero(v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestValidateCases(t *testing.T) {
+	type testcase struct {
+		input string `tbl:"required"`
+		want  []int  `tbl:"required"`
+		note  string
+	}
+	test := tbltest.Cases(
+		testcase{input: "a", want: []int{1}},
+		testcase{input: "b"},
+		testcase{note: "forgot everything"},
+	)
+	err := test.ValidateCases()
+	if err == nil {
+		t.Fatalf("expected test cases 1 and 2 to be missing required fields")
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a line for each of the two test cases, got %q", err)
+	}
+	if !strings.HasPrefix(lines[0], "test case 1 (1) is missing the required fields want, defined at ") {
+		t.Errorf("unexpected error for test case 1: %v", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "test case 2 (2) is missing the required fields input, want") {
+		t.Errorf("unexpected error for test case 2: %v", lines[1])
+	}
+	if _, err := test.TryRun(func(tc testcase) {}); err == nil {
+		t.Errorf("expected TryRun to return the missing fields")
+	}
+
+	ok := tbltest.Cases(&testcase{input: "a", want: []int{}})
+	if err := ok.ValidateCases(); err != nil {
+		t.Errorf("expected the test cases to be valid, got %v", err)
+	}
+	if err := tbltest.Cases(1, 2).ValidateCases(); err != nil {
+		t.Errorf("expected test cases that are not structs to be valid, got %v", err)
+	}
+}
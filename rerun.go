@@ -0,0 +1,98 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var rerunFailed = flagBool("RerunFailed", false, "Only run the test cases that failed in the last run, as recorded in "+stateFile+".")
+var recordFailed = flagBool("RecordFailures", false, "Record the test cases that failed in "+stateFile+", for the "+flagName("RerunFailed")+" option.")
+
+// stateFile is where the failed test cases of each table are recorded, relative to the package directory
+// that go test runs the tests in.
+const stateFile = ".tbl/last-failures.json"
+
+// stateMu serializes the updates of the state file by tables run in parallel.
+var stateMu sync.Mutex
+
+// tableKey identifies the table in the state file, by the test function that runs it and where its first
+// test case was defined.
+func (tc *Test) tableKey() string {
+	return callerTestName() + "@" + tc.cases[0].source
+}
+
+// readFailures returns the failed test cases of each table recorded in the state file.
+func readFailures() map[string][]int {
+	failures := make(map[string][]int)
+	data, err := ioutil.ReadFile(filepath.FromSlash(stateFile))
+	if err != nil {
+		return failures
+	}
+	if err := json.Unmarshal(data, &failures); err != nil {
+		logf("Ignoring %v, it is not valid: %v", stateFile, err)
+	}
+	return failures
+}
+
+// filterFailed returns the indexes in list of the test cases that failed in the last run of the table.
+func (tc *Test) filterFailed(list []int) []int {
+	stateMu.Lock()
+	failed := readFailures()[tc.tableKey()]
+	stateMu.Unlock()
+	last := make(map[int]bool, len(failed))
+	for _, idx := range failed {
+		last[idx] = true
+	}
+	var filtered []int
+	for _, idx := range list {
+		if last[idx] {
+			filtered = append(filtered, idx)
+		}
+	}
+	return filtered
+}
+
+// recordFailures records the test cases of the table that failed in the state file, for the tblTest.RerunFailed
+// option. They are only recorded if asked to, with the tblTest.RecordFailures or tblTest.RerunFailed options,
+// or with OrderFailedFirst; so the state file is not written into the package directory otherwise. Nothing is
+// written if the table had no failures, and none were recorded before.
+func (tc *Test) recordFailures(results []CaseResult) {
+	if !*recordFailed && !*rerunFailed && tc.Order != OrderFailedFirst {
+		return
+	}
+	var failed []int
+	for _, r := range results {
+		if r.Status.failed() {
+			failed = append(failed, r.Index)
+		}
+	}
+	key := tc.tableKey()
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	failures := readFailures()
+	if _, ok := failures[key]; !ok && len(failed) == 0 {
+		return
+	}
+	if len(failed) == 0 {
+		delete(failures, key)
+	} else {
+		failures[key] = failed
+	}
+	data, err := json.MarshalIndent(failures, "", "\t")
+	if err == nil {
+		path := filepath.FromSlash(stateFile)
+		if err = os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+			err = ioutil.WriteFile(path, append(data, '\n'), 0644)
+		}
+	}
+	if err != nil {
+		logf("Could not record the failed test cases in %v: %v", stateFile, err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRerunFailed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	fail := map[int]bool{1: true, 3: true}
+	run := func() (ran []int) {
+		test := tbltest.Cases(0, 1, 2, 3)
+		test.InOrder = true
+		test.ContinueOnFailure = true
+		test.Run(func(tc int) bool {
+			ran = append(ran, tc)
+			return !fail[tc]
+		})
+		return ran
+	}
+	if ran := run(); len(ran) != 4 {
+		t.Fatalf("expected all the test cases to run, got %v", ran)
+	}
+	if _, err := os.Stat(".tbl"); !os.IsNotExist(err) {
+		t.Fatalf("expected no failures to be recorded unless asked to, got %v", err)
+	}
+	flag.Set("tblTest.RecordFailures", "true")
+	run()
+	flag.Set("tblTest.RecordFailures", "false")
+
+	flag.Set("tblTest.RerunFailed", "true")
+	defer flag.Set("tblTest.RerunFailed", "false")
+	if ran := run(); fmt.Sprint(ran) != "[1 3]" {
+		t.Errorf("expected only the failed test cases to run again, got %v", ran)
+	}
+	// Once they pass, there is nothing left to rerun.
+	fail = nil
+	run()
+	if ran := run(); len(ran) != 0 {
+		t.Errorf("expected no test cases to run, got %v", ran)
+	}
+}
+
+func TestOrderFailedFirst(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	run := func(fail map[int]bool) (ran []int) {
+		test := tbltest.Cases(0, 1, 2, 3, 4)
+		test.Order = tbltest.OrderFailedFirst
+		test.ContinueOnFailure = true
+		test.Run(func(tc int) bool {
+			ran = append(ran, tc)
+			return !fail[tc]
+		})
+		return ran
+	}
+	if ran := run(map[int]bool{2: true, 4: true}); fmt.Sprint(ran) != "[0 1 2 3 4]" {
+		t.Errorf("expected the test cases to run in order the first time, got %v", ran)
+	}
+	if ran := run(nil); fmt.Sprint(ran) != "[2 4 0 1 3]" {
+		t.Errorf("expected the failed test cases to run first, got %v", ran)
+	}
+}
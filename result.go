@@ -0,0 +1,179 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var slowest = flagInt("Slowest", 0, "If not zero, log the given number of slowest test cases at the end of each run.")
+
+// defaultSlowest is the number of slowest test cases listed by Report, if the tblTest.Slowest option is not given.
+const defaultSlowest = 5
+
+// Status is the outcome of running a test case.
+type Status string
+
+const (
+	// StatusPass is the status of a test case that passed.
+	StatusPass Status = "pass"
+	// StatusFail is the status of a test case that failed; or that was expected to fail, but passed.
+	StatusFail Status = "fail"
+	// StatusXFail is the status of a test case marked with ExpectFail that failed, as expected.
+	StatusXFail Status = "xfail"
+	// StatusQuarantined is the status of a quarantined test case that failed, but was only reported.
+	StatusQuarantined Status = "quarantined"
+	// StatusWarn is the status of a test case that failed, but was only warned of, as it is less severe than
+	// the tblTest.FailOn option; see Rated.
+	StatusWarn Status = "warn"
+)
+
+// failed reports if the status counts as a failure of the test case.
+func (s Status) failed() bool {
+	return s == StatusFail || s == StatusQuarantined || s == StatusWarn
+}
+
+// CaseResult is the result of running a single test case.
+type CaseResult struct {
+	// Index is the index of the test case in the table.
+	Index int
+	// Name is the name of the test case.
+	Name string
+	// Status is the outcome of the test case.
+	Status Status
+	// Err describes why the test case failed, it is nil if the test case did not fail.
+	Err error
+	// Duration is the wall clock time the test function took.
+	Duration time.Duration
+	// Source is where the test case was defined, such as the file and line of the call to Cases that added it.
+	Source string
+	// Repro is the command to rerun the test case, if it failed.
+	Repro string
+}
+
+// Result is the result of a run of the table.
+type Result struct {
+	// Ran is the number of test cases that were run.
+	Ran int
+	// Passed is the number of test cases that passed, including those that failed as expected.
+	Passed int
+	// Failed is the number of test cases that failed.
+	Failed int
+	// Quarantined is the number of quarantined test cases that failed, without failing the run.
+	Quarantined int
+	// Warned is the number of test cases that failed, but were only warned of; see Rated.
+	Warned int
+	// Skipped is the number of test cases in the table that were not run.
+	Skipped int
+	// Order is the order the test cases were selected to run in.
+	Order []int
+	// Seed is the seed used to shuffle the test cases, or zero if they were not shuffled.
+	Seed int64
+	// Duration is the wall clock time of the whole run.
+	Duration time.Duration
+	// Cases are the results of each test case, in the order they ran.
+	Cases []CaseResult
+}
+
+func newResult(total int, order []int, seed int64, duration time.Duration, results []CaseResult) *Result {
+	r := Result{
+		Ran:      len(results),
+		Order:    order,
+		Seed:     seed,
+		Duration: duration,
+		Cases:    results,
+	}
+	ran := make(map[int]bool)
+	for _, cr := range results {
+		ran[cr.Index] = true
+		switch cr.Status {
+		case StatusPass, StatusXFail:
+			r.Passed++
+		case StatusFail:
+			r.Failed++
+		case StatusQuarantined:
+			r.Quarantined++
+		case StatusWarn:
+			r.Warned++
+		}
+	}
+	r.Skipped = total - len(ran)
+	return &r
+}
+
+// Results returns the results of the test cases that were run by the last call to Run, in the order they ran.
+func (tc *Test) Results() []CaseResult {
+	if tc.last == nil {
+		return nil
+	}
+	return tc.last.Cases
+}
+
+type byDuration []CaseResult
+
+func (s byDuration) Len() int           { return len(s) }
+func (s byDuration) Less(i, j int) bool { return s[i].Duration > s[j].Duration }
+func (s byDuration) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Slowest returns, slowest first, up to n of the test cases of the last run that took the longest.
+func (tc *Test) Slowest(n int) []CaseResult {
+	results := append([]CaseResult(nil), tc.Results()...)
+	sort.Stable(byDuration(results))
+	if n < len(results) {
+		results = results[:n]
+	}
+	return results
+}
+
+// Report returns a summary of the last run, listing the slowest test cases. The number of test cases listed
+// is set by the tblTest.Slowest option.
+func (tc *Test) Report() string {
+	n := *slowest
+	if n <= 0 {
+		n = defaultSlowest
+	}
+	var total time.Duration
+	results := tc.Results()
+	for _, r := range results {
+		total += r.Duration
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Ran %v test cases in %v; the slowest were:", len(results), total)
+	for _, r := range tc.Slowest(n) {
+		fmt.Fprintf(&buf, "\n\t%v: case %v (%v) %v", r.Duration, r.Index, r.Name, r.Status)
+	}
+	return buf.String()
+}
+
+// hasFailures returns whether any of the test cases failed.
+func hasFailures(results []CaseResult) bool {
+	for _, r := range results {
+		if r.Status.failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// logFailures logs the test cases that failed, formatted by f.
+func logFailures(f Formatter, results []CaseResult) {
+	var buf bytes.Buffer
+	failed := 0
+	for _, r := range results {
+		if r.Status != StatusFail {
+			continue
+		}
+		failed++
+		buf.WriteString("\n\t")
+		buf.WriteString(strings.Replace(f.FormatFailure(r), "\n", "\n\t", -1))
+	}
+	if failed > 0 {
+		logf("%v of %v test cases failed:%v", failed, len(results), buf.String())
+	}
+}
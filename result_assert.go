@@ -0,0 +1,43 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "testing"
+
+// FailedCases returns the results of the test cases that failed, in the order they ran. The test cases that
+// failed as expected, were quarantined, or were only warned of are left out.
+func (r *Result) FailedCases() []CaseResult {
+	var failed []CaseResult
+	for _, cr := range r.Cases {
+		if cr.Status == StatusFail {
+			failed = append(failed, cr)
+		}
+	}
+	return failed
+}
+
+// AssertNoFailures fails t with each of the test cases that failed, as returned by FailedCases.
+//
+//	test.RunResult(func(tc testcase) bool { ... }).AssertNoFailures(t)
+func (r *Result) AssertNoFailures(t testing.TB) {
+	t.Helper()
+	for _, cr := range r.FailedCases() {
+		t.Errorf("test case %v (%v) failed: %v", cr.Index, cr.Name, cr.Err)
+	}
+}
+
+// AssertRan fails t unless each of the named test cases was run.
+func (r *Result) AssertRan(t testing.TB, names ...string) {
+	t.Helper()
+	ran := make(map[string]bool, len(r.Cases))
+	for _, cr := range r.Cases {
+		ran[cr.Name] = true
+	}
+	for _, name := range names {
+		if !ran[name] {
+			t.Errorf("test case %q was not run", name)
+		}
+	}
+}
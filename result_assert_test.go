@@ -0,0 +1,34 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestResultAssertions(t *testing.T) {
+	test := tbltest.Cases(tbltest.Named(0, "zero"), tbltest.Named(1, "one"), tbltest.Tagged(tbltest.Named(2, "two"), "slow"))
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	r := test.RunResult(func(tc int) bool { return tc != 1 })
+
+	failed := r.FailedCases()
+	if len(failed) != 1 || failed[0].Name != "one" {
+		t.Errorf("expected test case one to have failed, got %+v", failed)
+	}
+	rt := &recordingT{TB: t}
+	r.AssertNoFailures(rt)
+	r.AssertRan(rt, "zero", "two", "three")
+	want := []string{
+		"test case 1 (one) failed: test function returned false",
+		`test case "three" was not run`,
+	}
+	if !reflect.DeepEqual(rt.errors, want) {
+		t.Errorf("expected the errors %q, got %q", want, rt.errors)
+	}
+}
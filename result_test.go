@@ -0,0 +1,180 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestSlowest(t *testing.T) {
+	test := tbltest.Cases(1, 30, 2, 20)
+	test.Run(func(tc int) {
+		time.Sleep(time.Duration(tc) * time.Millisecond)
+	})
+	if len(test.Results()) != 4 {
+		t.Fatalf("expected 4 results, got %v", len(test.Results()))
+	}
+	for _, r := range test.Results() {
+		if r.Status != tbltest.StatusPass {
+			t.Errorf("expected case %v to pass, got %v", r.Index, r.Status)
+		}
+	}
+	slowest := test.Slowest(2)
+	if len(slowest) != 2 || slowest[0].Index != 1 || slowest[1].Index != 3 {
+		t.Errorf("expected cases 1 and 3 to be the slowest, got %+v", slowest)
+	}
+	if report := test.Report(); !strings.Contains(report, "Ran 4 test cases") || !strings.Contains(report, "case 1 (1)") {
+		t.Errorf("unexpected report: %v", report)
+	}
+}
+
+func TestRunResult(t *testing.T) {
+	test := tbltest.Cases(0, tbltest.ExpectFail(1, "bug"), tbltest.Quarantine(2, "flaky"), 3, 4)
+	test.InOrder = true
+	result := test.RunResult(func(tc int) bool { return tc != 1 && tc != 2 && tc != 3 })
+	if result.Ran != 4 || result.Passed != 2 || result.Failed != 1 || result.Quarantined != 1 || result.Skipped != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(result.Order) != 5 || result.Seed != 0 || len(result.Cases) != 4 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Cases[3].Status != tbltest.StatusFail {
+		t.Errorf("expected case 3 to fail, got %v", result.Cases[3].Status)
+	}
+}
+
+func TestContinueOnFailure(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	test := tbltest.Cases(0, 1, 2, 3)
+	test.ContinueOnFailure = true
+	result := test.RunResult(func(tc int) bool { return tc%2 == 0 })
+	if result.Ran != 4 || result.Failed != 2 {
+		t.Errorf("expected all 4 cases to run and 2 to fail, got %+v", result)
+	}
+	if !strings.Contains(buf.String(), "2 of 4 test cases failed") || !strings.Contains(buf.String(), "case 3 (3)") {
+		t.Errorf("expected the failures to be logged, got log: %v", buf.String())
+	}
+}
+
+func TestDefinitionSite(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	_, _, line, _ := runtime.Caller(0)
+	test := tbltest.Cases(0)
+	test.AddCases(1)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	result := test.RunResult(func(tc int) bool { return false })
+	for i, cr := range result.Cases {
+		if want := fmt.Sprintf("result_test.go:%v", line+1+i); cr.Source != want {
+			t.Errorf("expected case %v to be defined at %v, got %v", i, want, cr.Source)
+		}
+	}
+	if want := fmt.Sprintf("case 1 (1) defined at result_test.go:%v: ", line+2); !strings.Contains(buf.String(), want) {
+		t.Errorf("expected the failure to give the definition site, got log: %v", buf.String())
+	}
+}
+
+func TestReproCommand(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	flag.Set("tblTest.Seed", "42")
+	defer flag.Set("tblTest.Seed", "0")
+
+	test := tbltest.Cases(0, 1, 2)
+	test.ContinueOnFailure = true
+	result := test.RunResult(func(tc int) bool { return tc != 1 })
+	want := "go test -run '^TestReproCommand$' -args -tblTest.RunOrder=1"
+	for _, cr := range result.Cases {
+		if cr.Index == 1 && cr.Repro != want {
+			t.Errorf("expected the command to rerun case 1 to be %q, got %q", want, cr.Repro)
+		}
+	}
+	for _, want := range []string{
+		"test function returned false\n\t\tto rerun it: " + want,
+		"to run them in the same order again: go test -run '^TestReproCommand$' -args -tblTest.Seed=42",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q to be logged, got log: %v", want, buf.String())
+		}
+	}
+
+	// Without ContinueOnFailure, returning false only stops the run.
+	buf.Reset()
+	tbltest.Cases(0, 1, 2).Run(func(tc int) bool { return tc != 1 })
+	if strings.Contains(buf.String(), "to rerun it") {
+		t.Errorf("expected no command to rerun a test case that returned false, got log: %v", buf.String())
+	}
+}
+
+func TestColor(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	defer flag.Set("tblTest.Color", "never")
+
+	run := func() {
+		buf.Reset()
+		test := tbltest.Cases(0, 1)
+		test.ContinueOnFailure = true
+		test.Run(func(tc int) bool { return tc == 0 })
+	}
+	flag.Set("tblTest.Color", "always")
+	run()
+	if !strings.Contains(buf.String(), "\x1b[1m\x1b[31mcase 1 (1)\x1b[0m") {
+		t.Errorf("expected the failed case to be colored, got log: %q", buf.String())
+	}
+	flag.Set("tblTest.Color", "never")
+	run()
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("expected no colors, got log: %q", buf.String())
+	}
+}
+
+type oneLineFormatter struct{}
+
+func (oneLineFormatter) FormatFailure(r tbltest.CaseResult) string {
+	return fmt.Sprintf("FAIL %v", r.Name)
+}
+
+func TestFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	tbltest.SetFormatter(oneLineFormatter{})
+	defer tbltest.SetFormatter(nil)
+	test := tbltest.Cases(0, 1)
+	test.ContinueOnFailure = true
+	test.Run(func(tc int) bool { return tc == 0 })
+	if !strings.Contains(buf.String(), "test cases failed:\n\tFAIL 1\n") {
+		t.Errorf("expected the failure to be formatted by the Formatter, got log: %v", buf.String())
+	}
+
+	buf.Reset()
+	test = tbltest.Cases(0, 1)
+	test.ContinueOnFailure = true
+	test.Formatter = tbltest.DefaultFormatter{}
+	test.Run(func(tc int) bool { return tc == 0 })
+	if !strings.Contains(buf.String(), "\tcase 1 (1) defined at ") {
+		t.Errorf("expected the table's Formatter to be used, got log: %v", buf.String())
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromRows creates a test case from each row of a table of strings, as read from a spreadsheet or a document.
+// The header names the field of proto's type, which must be a struct or a pointer to one, that each column is
+// parsed into; a header matches the field with the same name, ignoring case and spaces, so "Expected Output"
+// fills in the field ExpectedOutput, or expectedOutput. A column headed "name" that matches no field names
+// the test cases instead.
+//
+//	test, err := tbltest.FromRows([]string{"input", "want"}, [][]string{{"1+1", "2"}, {"2*3", "6"}}, testcase{})
+//
+// The fields may be strings, bools, numbers, time.Durations, pointers to those, or types that implement
+// encoding.TextUnmarshaler. An empty cell leaves a pointer field nil.
+func FromRows(header []string, rows [][]string, proto TestCase) (*Test, error) {
+	return fromRows(header, rows, nil, proto)
+}
+
+// fromRows is FromRows, recording sources[i], if given, as where row i was defined.
+func fromRows(header []string, rows [][]string, sources []string, proto TestCase) (*Test, error) {
+	vType := reflect.TypeOf(proto)
+	if vType == nil {
+		return nil, errors.New("proto is not a valid test case")
+	}
+	sType := vType
+	if sType.Kind() == reflect.Ptr {
+		sType = sType.Elem()
+	}
+	if sType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("the test cases should be structs, not %v", vType)
+	}
+	fields := make([]int, len(header))
+	nameCol := -1
+	for i, h := range header {
+		fields[i] = -1
+		key := columnKey(h)
+		for j := 0; j < sType.NumField(); j++ {
+			if columnKey(sType.Field(j).Name) == key {
+				fields[i] = j
+				break
+			}
+		}
+		if fields[i] == -1 {
+			if key != "name" || nameCol != -1 {
+				return nil, fmt.Errorf("column %q is not a field of %v", h, sType)
+			}
+			nameCol = i
+		}
+	}
+	tc := Test{vType: vType}
+	for r, row := range rows {
+		where := fmt.Sprintf("row %v", r+1)
+		if sources != nil {
+			where = sources[r]
+		}
+		if len(row) != len(header) {
+			return nil, fmt.Errorf("%v: has %v cells, the header has %v", where, len(row), len(header))
+		}
+		v := reflect.New(sType).Elem()
+		e := entry{}
+		for i, cell := range row {
+			if i == nameCol {
+				e.name = cell
+				continue
+			}
+			f := settableField(v, fields[i])
+			if err := setFromString(f, cell); err != nil {
+				return nil, fmt.Errorf("%v: column %q: %v", where, header[i], err)
+			}
+		}
+		if vType.Kind() == reflect.Ptr {
+			v = v.Addr()
+		}
+		e.value = v
+		if sources != nil {
+			e.source = sources[r]
+		}
+		tc.cases = append(tc.cases, e)
+	}
+	return &tc, nil
+}
+
+// columnKey is what is compared to match a column header to a field name.
+func columnKey(s string) string {
+	return strings.ToLower(strings.Replace(s, " ", "", -1))
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setFromString parses s into v, depending on the type of v.
+func setFromString(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		v.SetInt(int64(d))
+		return err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Ptr:
+		if s == "" {
+			return nil
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := setFromString(p.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(p)
+	default:
+		return fmt.Errorf("can not parse a %v from a string", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestFromRows(t *testing.T) {
+	type testcase struct {
+		input    string
+		timeout  time.Duration
+		retries  int
+		ratio    *float64
+		Optional bool
+	}
+	test, err := tbltest.FromRows(
+		[]string{"Input", "timeout", "Retries", "ratio", "optional", "name"},
+		[][]string{
+			{"a", "1s", "3", "0.5", "true", "first"},
+			{"b", "2ms", "0x10", "", "false", "second"},
+		},
+		&testcase{},
+	)
+	if err != nil {
+		t.Fatalf("failed to create the test cases: %v", err)
+	}
+	first := test.Case(0).(*testcase)
+	if first.input != "a" || first.timeout != time.Second || first.retries != 3 || first.ratio == nil || *first.ratio != 0.5 || !first.Optional {
+		t.Errorf("unexpected first test case %+v", first)
+	}
+	second := test.Case(1).(*testcase)
+	if second.retries != 16 || second.ratio != nil || second.Optional {
+		t.Errorf("unexpected second test case %+v", second)
+	}
+	if name := test.Name(1); name != "second" {
+		t.Errorf("expected the second test case to be named second, got %v", name)
+	}
+
+	for _, header := range [][]string{{"input", "missing"}, {"input"}} {
+		if _, err := tbltest.FromRows(header, [][]string{{"a", "b"}}, testcase{}); err == nil {
+			t.Errorf("expected an error for the header %v", header)
+		}
+	}
+	if _, err := tbltest.FromRows([]string{"retries"}, [][]string{{"many"}}, testcase{}); err == nil {
+		t.Errorf("expected an error for a number that does not parse")
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+var (
+	testingTType  = reflect.TypeOf((*testing.T)(nil))
+	testingTBType = reflect.TypeOf((*testing.TB)(nil)).Elem()
+)
+
+// RunT is like Run, but runs each test case as a subtest of t, named after the test case (see SubtestName), so
+// go test reports each test case on its own and -run can select them. The function must be of one of the forms:
+//
+//	func(t *testing.T, tc $testcase)
+//	func(t *testing.T, fx *tbltest.Fixture, tc $testcase)
+//	func(t testing.TB, tc $testcase)
+//	func(t testing.TB, fx *tbltest.Fixture, tc $testcase)
+//
+// and fails the test case by failing its t. With the second form, each test case is also given a Fixture of its
+// own, with a temporary directory and cleanup functions that end with the subtest. A test case can hold a table
+// of its own, which is run with RunT from the function to nest the subtests, as in TestCodecs/gzip/empty_input:
+//
+//	type codecCase struct {
+//		codec  Codec
+//		inputs *tbltest.Test
+//	}
+//
+//	codecs.RunT(t, func(t *testing.T, tc codecCase) {
+//		tc.inputs.RunT(t, func(t *testing.T, in inputCase) { ... })
+//	})
+//
+// The failure of a test case marked with ExpectFail or Quarantine, or less severe than the tblTest.FailOn option
+// (see Rated), must not fail the test, which a *testing.T can not be kept from doing, so such test cases need one
+// of the testing.TB forms. They are given a testing.TB that logs to their subtest but keeps their failures to
+// itself: a test case that fails as expected passes, a quarantined one that fails is skipped, and a failure that
+// is less severe than tblTest.FailOn is logged as a warning.
+//
+// The environment variables of the test cases (see SetEnv) are set with t.Setenv. The test cases are run one at
+// a time, in the run order of the table, so the subtests should not call t.Parallel.
+func (tc *Test) RunT(t *testing.T, function interface{}) int {
+	fn := reflect.ValueOf(function)
+	if fn.Kind() != reflect.Func {
+		panicf("RunT was not provided a function.")
+	}
+	fnType := fn.Type()
+	withFixture := fnType.NumIn() == 3 && fnType.In(1) == fixtureType
+	if (fnType.NumIn() != 2 && !withFixture) || (fnType.In(0) != testingTType && fnType.In(0) != testingTBType) || fnType.In(fnType.NumIn()-1) != tc.vType || fnType.NumOut() != 0 {
+		panicf("RunT should be given a function of the form func(t *testing.T, tc %v) or func(t *testing.T, fx *tbltest.Fixture, tc %[1]v), or the same with a testing.TB, was given %v", tc.vType, fnType)
+	}
+	names := tc.subtestNames()
+	var rn *runner
+	adapter := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{reflect.TypeOf(0), tc.vType}, []reflect.Type{reflect.TypeOf(true)}, false), func(args []reflect.Value) []reflect.Value {
+		idx := int(args[0].Int())
+		e := &tc.cases[idx]
+		call := func(t *testing.T, tb testing.TB) {
+			in := []reflect.Value{reflect.ValueOf(tb), args[1]}
+			if withFixture {
+				in = []reflect.Value{in[0], reflect.ValueOf(&Fixture{t: t}), in[1]}
+			}
+			fn.Call(in)
+		}
+		reported, passed := rn.reportsFailure(e), true
+		ran := t.Run(names[idx], func(t *testing.T) {
+			if env := e.environment(); len(env) > 0 {
+				setEnvT(t, env)
+			}
+			if !reported {
+				call(t, t)
+				return
+			}
+			passed = !runQuiet(t, call)
+			switch {
+			case e.quarantined && rn.reportQuarantined:
+				if !passed {
+					t.Skipf("Quarantined (%v), failed.", e.quarantineReason)
+				}
+			case e.expectFail:
+				if passed {
+					t.Errorf("Passed, but is marked as expected to fail: %v", e.xfailReason)
+				} else {
+					t.Logf("Failed as expected: %v", e.xfailReason)
+				}
+			default:
+				if !passed {
+					t.Logf("Warning: failed, but is less severe than -%v=%v.", flagName("FailOn"), *failOn)
+				}
+			}
+		})
+		if !reported {
+			// The test case failed if it failed its subtest.
+			passed = ran
+		}
+		return []reflect.Value{reflect.ValueOf(passed)}
+	})
+	rn = tc.newRunner(adapter.Interface())
+	if fnType.In(0) == testingTType {
+		for idx := range tc.cases {
+			if e := &tc.cases[idx]; e.expectFail || (e.quarantined && *quarantine != quarantineRun && *quarantine != quarantineSkip) || e.warnOnly(rn.failOn) {
+				panicf("Test case %v (%v) is marked with ExpectFail or Quarantine, or is less severe than -%v, so its failure must not fail the test, which a *testing.T can not be kept from doing; give RunT a function of the form func(t testing.TB, tc %v) instead.", idx, e.caseName(idx), flagName("FailOn"), tc.vType)
+			}
+		}
+	}
+	rn.envByT = true
+	rn.blocked = func(idx int, err error) {
+		t.Run(names[idx], func(t *testing.T) { t.Error(err) })
+	}
+	return tc.runWith(rn).Ran
+}
+
+// reportsFailure reports whether the failure of the test case is only reported, rather than failing the run, as
+// it is quarantined, expected to fail, or less severe than tblTest.FailOn.
+func (rn *runner) reportsFailure(e *entry) bool {
+	return (e.quarantined && rn.reportQuarantined) || e.expectFail || e.warnOnly(rn.failOn)
+}
+
+// runQuiet calls the test function of a test case whose failure is only reported with a quietT, in a
+// goroutine of its own so FailNow can end it, and reports if the test case failed.
+func runQuiet(t *testing.T, call func(t *testing.T, tb testing.TB)) (failed bool) {
+	qt := &quietT{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if rec := recover(); rec != nil {
+				qt.Errorf("panic: %v", rec)
+			}
+		}()
+		call(t, qt)
+	}()
+	<-done
+	return qt.Failed()
+}
+
+// quietT is the testing.TB given to a test case whose failure is only reported. It logs to the subtest of
+// the test case, but records its failures rather than failing the subtest.
+type quietT struct {
+	testing.TB
+	mu     sync.Mutex
+	failed bool
+}
+
+func (qt *quietT) Fail() {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+	qt.failed = true
+}
+
+func (qt *quietT) Failed() bool {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+	return qt.failed
+}
+
+func (qt *quietT) FailNow() {
+	qt.Fail()
+	runtime.Goexit()
+}
+
+func (qt *quietT) Error(args ...interface{}) {
+	qt.TB.Helper()
+	qt.TB.Log(args...)
+	qt.Fail()
+}
+
+func (qt *quietT) Errorf(format string, args ...interface{}) {
+	qt.TB.Helper()
+	qt.TB.Log(fmt.Sprintf(format, args...))
+	qt.Fail()
+}
+
+func (qt *quietT) Fatal(args ...interface{}) {
+	qt.TB.Helper()
+	qt.TB.Log(args...)
+	qt.FailNow()
+}
+
+func (qt *quietT) Fatalf(format string, args ...interface{}) {
+	qt.TB.Helper()
+	qt.TB.Log(fmt.Sprintf(format, args...))
+	qt.FailNow()
+}
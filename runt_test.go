@@ -0,0 +1,130 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRunT(t *testing.T) {
+	type codecCase struct {
+		codec  string
+		inputs *tbltest.Test
+	}
+	codecs := tbltest.Cases(
+		tbltest.Named(codecCase{codec: "gzip", inputs: tbltest.Cases(tbltest.Named("", "empty"), tbltest.Named("abc", "short"))}, "gzip"),
+		tbltest.Named(codecCase{codec: "zstd", inputs: tbltest.Cases("abc")}, "zstd"),
+	)
+	var names []string
+	count := codecs.RunT(t, func(t *testing.T, tc codecCase) {
+		tc.inputs.RunT(t, func(t *testing.T, in string) {
+			names = append(names, t.Name())
+		})
+	})
+	if count != 2 {
+		t.Errorf("expected to run 2 tests, ran %v instead", count)
+	}
+	sort.Strings(names)
+	want := []string{"TestRunT/gzip/empty", "TestRunT/gzip/short", "TestRunT/zstd/0"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("expected the subtests %v, got %v", want, names)
+	}
+}
+
+func TestRunTMarkedCases(t *testing.T) {
+	if os.Getenv(childEnv) == "1" {
+		test := tbltest.Cases(
+			tbltest.Named(tbltest.ExpectFail(0, "issue #12"), "xfail"),
+			tbltest.Named(tbltest.Quarantine(1, "flaky"), "flaky"),
+			tbltest.Named(2, "plain"),
+			tbltest.Named(tbltest.ExpectFail(3, "issue #7"), "xpass"),
+		)
+		test.InOrder = true
+		test.RunT(t, func(t testing.TB, tc int) {
+			if tc < 2 {
+				t.Fatalf("test case %v failed", tc)
+			}
+		})
+		return
+	}
+	out, passed := runChild(t, "TestRunTMarkedCases")
+	if passed {
+		t.Errorf("expected the unexpected pass to fail the test, got %s", out)
+	}
+	for _, want := range []string{
+		"--- PASS: TestRunTMarkedCases/xfail",
+		"test case 0 failed",
+		"Failed as expected: issue #12",
+		"--- SKIP: TestRunTMarkedCases/flaky",
+		"Quarantined (flaky), failed.",
+		"--- PASS: TestRunTMarkedCases/plain",
+		"--- FAIL: TestRunTMarkedCases/xpass",
+		"Passed, but is marked as expected to fail: issue #7",
+		"Test case 3 passed unexpectedly",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected the output to contain %q, got %s", want, out)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "give RunT a function of the form func(t testing.TB, tc int)") {
+			t.Errorf("expected RunT to reject a *testing.T for a test case expected to fail, got %v", r)
+		}
+	}()
+	tbltest.Cases(tbltest.ExpectFail(0, "issue #12")).RunT(t, func(t *testing.T, tc int) {})
+}
+
+func TestRunTFailOn(t *testing.T) {
+	if os.Getenv(childEnv) == "1" {
+		test := tbltest.Cases(
+			tbltest.Named(tbltest.Rated(0, tbltest.SeverityMinor), "minor"),
+			tbltest.Named(tbltest.Rated(1, tbltest.SeverityCritical), "critical"),
+		)
+		test.InOrder = true
+		test.RunT(t, func(t testing.TB, tc int) {
+			if tc == 0 {
+				t.Error("the minor test case failed")
+			}
+		})
+		return
+	}
+	out, passed := runChild(t, "TestRunTFailOn", "-tblTest.FailOn=major")
+	if !passed {
+		t.Errorf("expected the minor failure not to fail the test, got %s", out)
+	}
+	for _, want := range []string{
+		"--- PASS: TestRunTFailOn/minor",
+		"the minor test case failed",
+		"Warning: failed, but is less severe than -tblTest.FailOn=major.",
+		"--- PASS: TestRunTFailOn/critical",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected the output to contain %q, got %s", want, out)
+		}
+	}
+	if out, passed := runChild(t, "TestRunTFailOn"); passed || !strings.Contains(out, "--- FAIL: TestRunTFailOn/minor") {
+		t.Errorf("expected the minor failure to fail the test without -tblTest.FailOn, got %s", out)
+	}
+}
+
+// childEnv is set in the environment of the child processes started by runChild.
+const childEnv = "TBLTEST_RUNT_CHILD"
+
+// runChild runs the test again in a child process, with childEnv set, so the test can fail its subtests there
+// without failing here. It returns the verbose output of the child, and if it passed.
+func runChild(t *testing.T, test string, args ...string) (out string, passed bool) {
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=^" + test + "$", "-test.v"}, args...)...)
+	cmd.Env = append(os.Environ(), childEnv+"=1")
+	b, err := cmd.CombinedOutput()
+	return string(b), err == nil
+}
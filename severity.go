@@ -0,0 +1,90 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+var failOn = flagString("FailOn", "minor", "The least severity of the test cases whose failures fail the run: minor (all of them), major or critical. The failures of less severe test cases are only warned of; see tbltest.Rated.")
+
+// Severity is how much the failure of a test case matters.
+type Severity int
+
+const (
+	// SeverityMajor is the severity of the test cases that are not given one.
+	SeverityMajor Severity = iota
+	// SeverityMinor is for the test cases whose failures can wait.
+	SeverityMinor
+	// SeverityCritical is for the test cases whose failures must never be let through.
+	SeverityCritical
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityMajor:
+		return "major"
+	case SeverityMinor:
+		return "minor"
+	case SeverityCritical:
+		return "critical"
+	}
+	return "unknown"
+}
+
+// rank orders the severities from the least to the most severe.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityMinor:
+		return 0
+	case SeverityCritical:
+		return 2
+	}
+	return 1
+}
+
+// Rated gives the test case a severity. With the tblTest.FailOn option, the failures of the test cases less severe
+// than it are only warned of, with the status StatusWarn: they are logged, but do not stop the run or count as
+// failures. So a large conformance table can be adopted bit by bit, with -tblTest.FailOn=major, while the minor
+// test cases are still being fixed.
+//
+//	test := tbltest.Cases(
+//		tbltest.Rated(testcase{input: "\x00"}, tbltest.SeverityCritical),
+//		tbltest.Rated(testcase{input: "  padded"}, tbltest.SeverityMinor),
+//	)
+func Rated(tcase TestCase, severity Severity) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.severity = severity
+	})
+}
+
+// WithSeverity is an Option that gives the test cases a severity (see Rated).
+func WithSeverity(severity Severity) Option {
+	return func(tcase TestCase) TestCase {
+		return Rated(tcase, severity)
+	}
+}
+
+// Severity returns the severity of the test case at the given index.
+func (tc *Test) Severity(idx int) Severity {
+	if idx < 0 || idx >= len(tc.cases) {
+		return SeverityMajor
+	}
+	return tc.cases[idx].severity
+}
+
+// failOnSeverity returns the value of the tblTest.FailOn option, defaulting to minor for unknown values. It is
+// called once for each run, so an unknown value is logged once rather than for every failure.
+func failOnSeverity() Severity {
+	for _, s := range []Severity{SeverityMinor, SeverityMajor, SeverityCritical} {
+		if *failOn == s.String() {
+			return s
+		}
+	}
+	logf("Unknown value %q for %v, using %q.", *failOn, flagName("FailOn"), SeverityMinor)
+	return SeverityMinor
+}
+
+// warnOnly reports whether a failure of the test case is only warned of, as it is less severe than failOn.
+func (e *entry) warnOnly(failOn Severity) bool {
+	return e.severity.rank() < failOn.rank()
+}
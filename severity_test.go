@@ -0,0 +1,62 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRated(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	test := tbltest.Cases(
+		tbltest.Rated(0, tbltest.SeverityMinor),
+		1,
+		tbltest.Group("critical", []tbltest.Option{tbltest.WithSeverity(tbltest.SeverityCritical)}, 2),
+	)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	if got := test.Severity(2); got != tbltest.SeverityCritical {
+		t.Errorf("expected test case 2 to be critical, got %v", got)
+	}
+	fail := func(tc int) bool { return false }
+	for _, tt := range []struct {
+		failOn         string
+		failed, warned int
+	}{
+		{"minor", 3, 0},
+		{"major", 2, 1},
+		{"critical", 1, 2},
+	} {
+		flag.Set("tblTest.FailOn", tt.failOn)
+		r := test.RunResult(fail)
+		if r.Failed != tt.failed || r.Warned != tt.warned {
+			t.Errorf("with -tblTest.FailOn=%v, expected %v failed and %v warned, got %v and %v", tt.failOn, tt.failed, tt.warned, r.Failed, r.Warned)
+		}
+	}
+	flag.Set("tblTest.FailOn", "minor")
+	if !strings.Contains(buf.String(), "Warning: 2 test cases less severe than -tblTest.FailOn=critical failed: 0, 1") {
+		t.Errorf("expected the warned failures to be logged, got %q", buf.String())
+	}
+
+	buf.Reset()
+	flag.Set("tblTest.FailOn", "severe")
+	defer flag.Set("tblTest.FailOn", "minor")
+	if r := test.RunResult(fail); r.Failed != 3 {
+		t.Errorf("with an unknown -tblTest.FailOn, expected all 3 test cases to fail, got %v", r.Failed)
+	}
+	if n := strings.Count(buf.String(), `Unknown value "severe" for tblTest.FailOn`); n != 1 {
+		t.Errorf("expected the unknown value to be logged once, was logged %v times: %q", n, buf.String())
+	}
+}
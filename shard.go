@@ -0,0 +1,46 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"strconv"
+	"strings"
+)
+
+var shard = flagString("Shard", "", "Only run the shard of the test cases given as index/total, e.g. 3/8. The index starts at zero.")
+
+// parseShard parses a shard given as index/total.
+func parseShard(s string) (index, total int, ok bool) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	total, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || total <= 0 || index < 0 || index >= total {
+		return 0, 0, false
+	}
+	return index, total, true
+}
+
+// filterShard returns the indexes in list that belong to the shard. Test cases are assigned to the shards
+// round-robin by their index in the table, so the shards are the same whatever order the cases run in. An
+// invalid shard panics, rather than have every shard of a mistyped CI job run all the test cases.
+func filterShard(s string, list []int) []int {
+	index, total, ok := parseShard(s)
+	if !ok {
+		panicf("Invalid value %q for %v, expected index/total with 0 <= index < total.", s, flagName("Shard"))
+	}
+	var filtered []int
+	for _, idx := range list {
+		if idx%total == index {
+			filtered = append(filtered, idx)
+		}
+	}
+	return filtered
+}
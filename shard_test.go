@@ -0,0 +1,94 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestShard(t *testing.T) {
+	defer flag.Set("tblTest.Shard", "")
+
+	test := tbltest.Generate(20, func(idx int) int { return idx })
+	seen := make(map[int]int)
+	for _, s := range []string{"0/3", "1/3", "2/3"} {
+		flag.Set("tblTest.Shard", s)
+		test.Run(func(tc int) {
+			seen[tc]++
+		})
+	}
+	if len(seen) != 20 {
+		t.Errorf("expected the shards to cover all 20 test cases, covered %v", len(seen))
+	}
+	for tc, n := range seen {
+		if n != 1 {
+			t.Errorf("expected test case %v to be in exactly one shard, was in %v", tc, n)
+		}
+	}
+
+	flag.Set("tblTest.Shard", "3/3")
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), `Invalid value "3/3" for tblTest.Shard`) {
+			t.Errorf("expected an invalid shard to panic, got %v", r)
+		}
+	}()
+	test.Run(func(tc int) {
+		t.Errorf("test case %v ran with an invalid shard", tc)
+	})
+}
+
+func TestShardTimings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tbltest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	_, _, line, _ := runtime.Caller(0)
+	test := tbltest.Generate(11, func(idx int) int { return idx })
+	// Test case 0 takes as long as the other ten together. Another table run from the same test function takes
+	// as long for test case 10, which must not be mixed up with this one.
+	var cases, other []string
+	for i := 0; i <= 10; i++ {
+		d, o := 10000000, 10000000
+		if i == 0 {
+			d = 100000000
+		}
+		if i == 10 {
+			o = 100000000
+		}
+		cases = append(cases, fmt.Sprintf(`{"index": %v, "name": "%[1]v", "status": "pass", "duration_ns": %v}`, i, d))
+		other = append(other, fmt.Sprintf(`{"index": %v, "name": "%[1]v", "status": "pass", "duration_ns": %v}`, i, o))
+	}
+	report := fmt.Sprintf(`{"version": 1, "runs": [
+		{"test": "TestShardTimings", "table": "TestShardTimings@shard_test.go:%v", "order": [], "cases": [%v]},
+		{"test": "TestShardTimings", "table": "TestShardTimings@shard_test.go:%v", "order": [], "cases": [%v]}
+	]}`, line+1, strings.Join(cases, ","), line+30, strings.Join(other, ","))
+	path := filepath.Join(dir, "report.json")
+	if err := ioutil.WriteFile(path, []byte(report), 0644); err != nil {
+		t.Fatal(err)
+	}
+	flag.Set("tblTest.ShardTimings", path)
+	defer flag.Set("tblTest.ShardTimings", "")
+	defer flag.Set("tblTest.Shard", "")
+
+	test.InOrder = true
+	shards := make([][]int, 2)
+	for i := range shards {
+		flag.Set("tblTest.Shard", fmt.Sprintf("%v/2", i))
+		test.Run(func(tc int) { shards[i] = append(shards[i], tc) })
+	}
+	if fmt.Sprint(shards) != "[[0] [1 2 3 4 5 6 7 8 9 10]]" {
+		t.Errorf("expected the shards to be balanced by duration, got %v", shards)
+	}
+}
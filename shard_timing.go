@@ -0,0 +1,119 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+)
+
+var shardTimings = flagString("ShardTimings", "", "A JSON report of a previous run (see tblTest.JSON) used to balance the shards of tblTest.Shard by the durations of the test cases.")
+
+var timings struct {
+	sync.Mutex
+	path string
+	// durations of the test cases, by table and index; see tableKey.
+	durations map[string]map[int]time.Duration
+}
+
+// caseDurations returns the durations of the test cases of the table, identified as by tableKey, recorded in the
+// JSON report at path. The report is read once.
+func caseDurations(path, table string) (map[int]time.Duration, error) {
+	timings.Lock()
+	defer timings.Unlock()
+	if timings.durations == nil || timings.path != path {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var report jsonReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, err
+		}
+		durations := make(map[string]map[int]time.Duration)
+		for _, run := range report.Runs {
+			if durations[run.Table] == nil {
+				durations[run.Table] = make(map[int]time.Duration)
+			}
+			for _, c := range run.Cases {
+				durations[run.Table][c.Index] = time.Duration(c.Duration)
+			}
+		}
+		timings.path, timings.durations = path, durations
+	}
+	return timings.durations[table], nil
+}
+
+// byLongest sorts indexes of test cases by their duration, longest first, and then by index.
+type byLongest struct {
+	idxs      []int
+	durations []time.Duration
+}
+
+func (b byLongest) Len() int      { return len(b.idxs) }
+func (b byLongest) Swap(i, j int) { b.idxs[i], b.idxs[j] = b.idxs[j], b.idxs[i] }
+func (b byLongest) Less(i, j int) bool {
+	di, dj := b.durations[b.idxs[i]], b.durations[b.idxs[j]]
+	if di != dj {
+		return di > dj
+	}
+	return b.idxs[i] < b.idxs[j]
+}
+
+// filterShardByTime is like filterShard, but assigns the test cases to the shards so that each shard has about
+// the same total duration, going by the durations in the tblTest.ShardTimings report. Each test case, longest
+// first, goes to the shard with the least total so far; test cases missing from the report are taken to
+// last the average. As the assignment only depends on the report, every shard agrees on it.
+func (tc *Test) filterShardByTime(s string, list []int) []int {
+	index, total, ok := parseShard(s)
+	if !ok {
+		return filterShard(s, list)
+	}
+	known, err := caseDurations(*shardTimings, tc.tableKey())
+	if err != nil {
+		logf("Could not read the timings in %v, assigning the shards round-robin: %v", *shardTimings, err)
+		return filterShard(s, list)
+	}
+	if len(known) == 0 {
+		return filterShard(s, list)
+	}
+	var sum time.Duration
+	for _, d := range known {
+		sum += d
+	}
+	average := sum / time.Duration(len(known))
+	durations := make([]time.Duration, len(tc.cases))
+	for i := range durations {
+		d, ok := known[i]
+		if !ok {
+			d = average
+		}
+		durations[i] = d
+	}
+	order := byLongest{idxs: seq(len(tc.cases)), durations: durations}
+	sort.Sort(order)
+	loads := make([]time.Duration, total)
+	assigned := make([]int, len(tc.cases))
+	for _, idx := range order.idxs {
+		least := 0
+		for i := range loads {
+			if loads[i] < loads[least] {
+				least = i
+			}
+		}
+		loads[least] += durations[idx]
+		assigned[idx] = least
+	}
+	var filtered []int
+	for _, idx := range list {
+		if idx >= 0 && idx < len(assigned) && assigned[idx] == index {
+			filtered = append(filtered, idx)
+		}
+	}
+	return filtered
+}
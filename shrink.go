@@ -0,0 +1,58 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// maxShrinkSteps bounds the number of variations tried while shrinking a failing test case.
+const maxShrinkSteps = 1000
+
+// shrinkFunc checks that the Shrink function is of the form func(tc $testcase) []$testcase.
+func (tc *Test) shrinkFunc() reflect.Value {
+	fn, err := tc.checkShrinkFunc()
+	if err != nil {
+		panicf("%v", err)
+	}
+	return fn
+}
+
+func (tc *Test) checkShrinkFunc() (reflect.Value, error) {
+	fn := reflect.ValueOf(tc.Shrink)
+	if fn.Kind() != reflect.Func {
+		return fn, errors.New("Shrink was not provided a function.")
+	}
+	fnType := fn.Type()
+	if fnType.NumIn() != 1 || fnType.In(0) != tc.vType || fnType.NumOut() != 1 || fnType.Out(0) != reflect.SliceOf(tc.vType) {
+		return fn, fmt.Errorf("Shrink function should be of the form func(tc %v) []%[1]v, was given %v", tc.vType, fnType)
+	}
+	return fn, nil
+}
+
+// shrinkCase repeatedly replaces the failing test case with the first of its shrunk variations that
+// still fails, and logs the last one found.
+func shrinkCase(shrink reflect.Value, call CaseFunc, idx int, testcase reflect.Value) reflect.Value {
+	steps := 0
+	for steps < maxShrinkSteps {
+		shrunk := false
+		variations := shrink.Call([]reflect.Value{testcase})[0]
+		for i := 0; i < variations.Len() && steps < maxShrinkSteps; i++ {
+			steps++
+			if call(idx, variations.Index(i).Interface()) != nil {
+				testcase = variations.Index(i)
+				shrunk = true
+				break
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	logf("Test case %v failed; the smallest failing variation found in %v steps is: %#v", idx, steps, testcase.Interface())
+	return testcase
+}
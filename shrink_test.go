@@ -0,0 +1,43 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestShrink(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	test := tbltest.Cases(1000)
+	test.Shrink = func(tc int) []int {
+		if tc == 0 {
+			return nil
+		}
+		return []int{tc / 2, tc - 1}
+	}
+	var failures int
+	test.Run(func(tc int) bool {
+		if tc >= 10 {
+			failures++
+			return false
+		}
+		return true
+	})
+	if failures < 2 {
+		t.Errorf("expected the shrunk variations to be run, only %v failed", failures)
+	}
+	if !strings.Contains(buf.String(), "is: 10") {
+		t.Errorf("expected the smallest failing case to be 10, got log: %v", buf.String())
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var signalReport = flagBool("SignalReport", false, "On SIGQUIT or SIGTERM, print the test case that is running and the test cases run so far, before the signal takes effect.")
+
+// activeRun is what is printed about a run of a table that is going on when a signal is received.
+type activeRun struct {
+	test string
+	// order are the test cases started so far, in order.
+	order []int
+	// idx, name and start are of the last test case started, which is still running if running is set.
+	idx     int
+	name    string
+	start   time.Time
+	running bool
+}
+
+// active are the runs going on, tracked only with the tblTest.SignalReport option.
+var active = struct {
+	sync.Mutex
+	runs map[*runner]*activeRun
+}{runs: make(map[*runner]*activeRun)}
+
+var signalOnce sync.Once
+
+// trackStart records that the test case started.
+func (rn *runner) trackStart(idx int, name string, start time.Time) {
+	if !*signalReport {
+		return
+	}
+	signalOnce.Do(notifySignals)
+	active.Lock()
+	defer active.Unlock()
+	r := active.runs[rn]
+	if r == nil {
+		r = &activeRun{test: callerTestName()}
+		active.runs[rn] = r
+	}
+	r.order = append(r.order, idx)
+	r.idx, r.name, r.start, r.running = idx, name, start, true
+}
+
+// trackFinish records that the last test case started finished.
+func (rn *runner) trackFinish() {
+	active.Lock()
+	defer active.Unlock()
+	if r := active.runs[rn]; r != nil {
+		r.running = false
+	}
+}
+
+// trackDone records that the run finished.
+func (rn *runner) trackDone() {
+	active.Lock()
+	defer active.Unlock()
+	delete(active.runs, rn)
+}
+
+// reportRuns writes what each of the active runs is doing to w, on receiving the signal.
+func reportRuns(w io.Writer, sig os.Signal) {
+	active.Lock()
+	defer active.Unlock()
+	if len(active.runs) == 0 {
+		fmt.Fprintf(w, "tbltest: received %v, no tables are running\n", sig)
+		return
+	}
+	for _, r := range active.runs {
+		if r.running {
+			fmt.Fprintf(w, "tbltest: received %v while %v was running test case %v (%v), started at %v, %v ago\n", sig, r.test, r.idx, r.name, r.start.Format(verboseTimeFormat), time.Since(r.start))
+		} else {
+			fmt.Fprintf(w, "tbltest: received %v while %v was between test cases\n", sig, r.test)
+		}
+		fmt.Fprintf(w, "\tthe test cases run so far, in order: %v\n", summarizeIndexes(r.order))
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build !plan9
+// +build !plan9
+
+package tbltest
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifySignals starts printing the active runs on SIGQUIT or SIGTERM. Once printed, the signal is raised again
+// with its default handling, so SIGQUIT still dumps the goroutines, and both end the test binary.
+func notifySignals() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGQUIT, syscall.SIGTERM)
+	go func() {
+		sig := <-ch
+		reportRuns(os.Stderr, sig)
+		signal.Reset(syscall.SIGQUIT, syscall.SIGTERM)
+		if p, err := os.FindProcess(os.Getpid()); err == nil {
+			p.Signal(sig)
+		}
+	}()
+}
@@ -0,0 +1,11 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build plan9
+// +build plan9
+
+package tbltest
+
+// notifySignals does nothing, as Plan 9 has notes rather than SIGQUIT and SIGTERM.
+func notifySignals() {}
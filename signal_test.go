@@ -0,0 +1,47 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package tbltest_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestSignalReport(t *testing.T) {
+	if os.Getenv("TBLTEST_SIGNAL_CHILD") == "1" {
+		// In the child process, terminate the test binary from within the third test case.
+		test := tbltest.Cases(0, 1, tbltest.Named(2, "hangs"))
+		test.InOrder = true
+		test.Run(func(tc int) {
+			if tc == 2 {
+				syscall.Kill(os.Getpid(), syscall.SIGTERM)
+				select {}
+			}
+		})
+		return
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSignalReport$", "-tblTest.SignalReport")
+	cmd.Env = append(os.Environ(), "TBLTEST_SIGNAL_CHILD=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected the child process to be terminated, got %s", out)
+	}
+	for _, want := range []string{
+		"tbltest: received terminated while TestSignalReport was running test case 2 (hangs), started at ",
+		"the test cases run so far, in order: 0, 1, 2",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected the output to contain %q, got %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "reflect"
+
+// FromSlice creates a table of the elements of slice, which must be a slice or an array of test cases. Unlike
+// Cases, it takes a slice that already exists as is, without it being spread into a list of TestCase values.
+// The table uses the elements of slice itself, so changes made to them before the table is run are seen by it.
+//
+//	var cases = []testcase{...}
+//	test := tbltest.FromSlice(cases)
+func FromSlice(slice interface{}) *Test {
+	return fromSlice(callerFileLine(), reflect.ValueOf(slice))
+}
+
+// fromSlice creates a table of the elements of v, defined at source.
+func fromSlice(source string, v reflect.Value) *Test {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		panicf("FromSlice should be given a slice of test cases, was given %v", v.Kind())
+	}
+	tc := Test{}
+	if v.Type().Elem().Kind() == reflect.Interface {
+		// The elements may be marked test cases, or not all of the same type.
+		testcases := make([]TestCase, v.Len())
+		for i := range testcases {
+			testcases[i] = v.Index(i).Interface()
+		}
+		if err := tc.addCases(source, testcases); err != nil {
+			panicf("%v", err)
+		}
+		return &tc
+	}
+	tc.vType = v.Type().Elem()
+	tc.cases = make([]entry, v.Len())
+	for i := range tc.cases {
+		tc.cases[i] = entry{value: v.Index(i), source: source}
+	}
+	return &tc
+}
@@ -0,0 +1,17 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package tbltest
+
+import "reflect"
+
+// OfSlice is like FromSlice, with the type of the test cases checked when compiling.
+//
+//	test := tbltest.OfSlice(cases)
+func OfSlice[T any](cases []T) *Test {
+	return fromSlice(callerFileLine(), reflect.ValueOf(cases))
+}
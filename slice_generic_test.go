@@ -0,0 +1,21 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestOfSlice(t *testing.T) {
+	var sum int
+	if n := tbltest.OfSlice([]int{1, 2, 3}).Run(func(tc int) { sum += tc }); n != 3 || sum != 6 {
+		t.Errorf("expected 3 test cases summing to 6, got %v summing to %v", n, sum)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestFromSlice(t *testing.T) {
+	type testcase struct {
+		in, want int
+	}
+	cases := []testcase{{1, 2}, {2, 4}, {3, 6}}
+	test := tbltest.FromSlice(cases)
+	// The table uses the elements of the slice itself.
+	cases[2].want = 7
+	test.InOrder = true
+	var failed []int
+	test.ContinueOnFailure = true
+	test.Run(func(idx int, tc testcase) bool {
+		if tc.in*2 != tc.want {
+			failed = append(failed, idx)
+			return false
+		}
+		return true
+	})
+	if len(failed) != 1 || failed[0] != 2 {
+		t.Errorf("expected test case 2 to fail, got %v", failed)
+	}
+
+	test = tbltest.FromSlice([]interface{}{tbltest.Named(1, "one"), 2})
+	if got := test.Name(0); got != "one" {
+		t.Errorf("expected test case 0 to be named one, got %v", got)
+	}
+	if n := tbltest.FromSlice([0]int{}).Run(func(int) {}); n != 0 {
+		t.Errorf("expected no test cases to run, got %v", n)
+	}
+}
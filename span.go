@@ -0,0 +1,68 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"sync"
+	"time"
+)
+
+// CaseSpan describes the run of a single test case, for a SpanExporter.
+type CaseSpan struct {
+	// Test is the name of the test function that ran the table.
+	Test string
+	// Index is the index of the test case in the table.
+	Index int
+	// Name is the name of the test case.
+	Name string
+	// Tags are the tags the test case was marked with.
+	Tags []string
+	// Status is the outcome of the test case.
+	Status Status
+	// Err describes why the test case failed, it is nil if the test case did not fail.
+	Err error
+	// Start is when the test case started.
+	Start time.Time
+	// Duration is the wall clock time the test case took.
+	Duration time.Duration
+}
+
+// SpanExporter is given a CaseSpan after each test case runs, to send on to a tracing or metrics system. This
+// package has no dependencies, so it is up to the exporter to create the spans; for example with OpenTelemetry:
+//
+//	type otelExporter struct{ tracer trace.Tracer }
+//
+//	func (e otelExporter) ExportSpan(s tbltest.CaseSpan) {
+//		_, span := e.tracer.Start(context.Background(), s.Test+"/"+s.Name, trace.WithTimestamp(s.Start),
+//			trace.WithAttributes(attribute.Int("tbl.index", s.Index), attribute.StringSlice("tbl.tags", s.Tags)))
+//		if s.Err != nil {
+//			span.SetStatus(codes.Error, s.Err.Error())
+//		}
+//		span.End(trace.WithTimestamp(s.Start.Add(s.Duration)))
+//	}
+//
+// ExportSpan is called from the goroutine running the test, and should not block for long.
+type SpanExporter interface {
+	ExportSpan(span CaseSpan)
+}
+
+var spans struct {
+	sync.RWMutex
+	exporter SpanExporter
+}
+
+// SetSpanExporter sets the exporter given the spans of all the test cases run from then on, usually from
+// TestMain. A nil exporter stops the exporting.
+func SetSpanExporter(e SpanExporter) {
+	spans.Lock()
+	spans.exporter = e
+	spans.Unlock()
+}
+
+func spanExporter() SpanExporter {
+	spans.RLock()
+	defer spans.RUnlock()
+	return spans.exporter
+}
@@ -0,0 +1,35 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+type spanRecorder []tbltest.CaseSpan
+
+func (r *spanRecorder) ExportSpan(span tbltest.CaseSpan) { *r = append(*r, span) }
+
+func TestSpanExporter(t *testing.T) {
+	var spans spanRecorder
+	tbltest.SetSpanExporter(&spans)
+	defer tbltest.SetSpanExporter(nil)
+
+	test := tbltest.Cases(0, tbltest.Tagged(1, "slow"))
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	test.Run(func(tc int) bool { return tc == 0 })
+	if len(spans) != 2 {
+		t.Fatalf("expected a span for each test case, got %v", spans)
+	}
+	if s := spans[0]; s.Test != "TestSpanExporter" || s.Index != 0 || s.Status != tbltest.StatusPass || s.Start.IsZero() {
+		t.Errorf("unexpected span for test case 0: %+v", s)
+	}
+	if s := spans[1]; s.Status != tbltest.StatusFail || s.Err == nil || len(s.Tags) != 1 || s.Tags[0] != "slow" {
+		t.Errorf("unexpected span for test case 1: %+v", s)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Stage is one step of a pipeline a test case is run through by RunStages.
+type Stage struct {
+	// Name describes the stage in the failures, such as "parse". If empty, the stage is named by its position.
+	Name string
+	// Func runs the stage. It is given the test case, and the result of the stage before, if that stage had one;
+	// it must be of one of the forms:
+	//
+	//	func(tc $testcase[, in $previous]) error
+	//	func(tc $testcase[, in $previous]) ($result, error)
+	Func interface{}
+}
+
+// RunStages runs each test case through the stages in order, each given the result of the one before, and stops
+// at the first stage that returns an error, failing the test case with the name of the stage. This splits one
+// large test function into the steps it goes through:
+//
+//	test.RunStages(
+//		tbltest.Stage{Name: "parse", Func: func(tc testcase) (*ast.File, error) { ... }},
+//		tbltest.Stage{Name: "check", Func: func(tc testcase, f *ast.File) (*types.Package, error) { ... }},
+//		tbltest.Stage{Name: "verify", Func: func(tc testcase, pkg *types.Package) error { ... }},
+//	)
+//
+// Otherwise RunStages runs the table like Run, and returns the number of test cases run.
+func (tc *Test) RunStages(stages ...Stage) int {
+	if len(stages) == 0 {
+		panicf("RunStages should be given at least one stage.")
+	}
+	fns := make([]reflect.Value, len(stages))
+	names := make([]string, len(stages))
+	// prev is the type of the result of the stage before, or nil if it had none.
+	var prev reflect.Type
+	for i, s := range stages {
+		names[i] = s.Name
+		if names[i] == "" {
+			names[i] = fmt.Sprintf("%v", i)
+		}
+		fn := reflect.ValueOf(s.Func)
+		want := "func(tc $testcase) error or func(tc $testcase) ($result, error)"
+		if prev != nil {
+			want = fmt.Sprintf("func(tc $testcase, in %v) error or func(tc $testcase, in %[1]v) ($result, error)", prev)
+		}
+		if fn.Kind() != reflect.Func {
+			panicf("Stage %v should be of the form %v, was given %T", names[i], want, s.Func)
+		}
+		fnType := fn.Type()
+		ins := 1
+		if prev != nil {
+			ins = 2
+		}
+		if fnType.NumIn() != ins || (tc.vType != nil && fnType.In(0) != tc.vType) || (prev != nil && fnType.In(1) != prev) ||
+			fnType.NumOut() < 1 || fnType.NumOut() > 2 || fnType.Out(fnType.NumOut()-1) != errorType {
+			panicf("Stage %v should be of the form %v, was given %v", names[i], want, fnType)
+		}
+		prev = nil
+		if fnType.NumOut() == 2 {
+			prev = fnType.Out(0)
+		}
+		fns[i] = fn
+	}
+	rn := tc.newCheckRunner(func(idx int, tcase TestCase) error {
+		in := []reflect.Value{reflect.ValueOf(tcase)}
+		for i, fn := range fns {
+			out := fn.Call(in)
+			if err, _ := out[len(out)-1].Interface().(error); err != nil {
+				return fmt.Errorf("stage %v failed: %v", names[i], err)
+			}
+			in = in[:1]
+			if len(out) == 2 {
+				in = append(in, out[0])
+			}
+		}
+		return nil
+	})
+	return tc.runWith(rn).Ran
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRunStages(t *testing.T) {
+	type testcase struct {
+		input string
+		want  int
+	}
+	test := tbltest.Cases(
+		testcase{input: "2", want: 4},
+		testcase{input: "x", want: 0},
+		testcase{input: "-1", want: 1},
+	)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	test.RunStages(
+		tbltest.Stage{Name: "parse", Func: func(tc testcase) (int, error) { return strconv.Atoi(tc.input) }},
+		tbltest.Stage{Name: "validate", Func: func(tc testcase, n int) error {
+			if n < 0 {
+				return errors.New("negative")
+			}
+			return nil
+		}},
+		tbltest.Stage{Func: func(tc testcase) (int, error) { return tc.want, nil }},
+	)
+	want := map[int]string{
+		1: `stage parse failed: strconv.Atoi: parsing "x": invalid syntax`,
+		2: "stage validate failed: negative",
+	}
+	for _, cr := range test.Results() {
+		var got string
+		if cr.Err != nil {
+			got = cr.Err.Error()
+		}
+		if got != want[cr.Index] {
+			t.Errorf("test case %v: expected the error %q, got %q", cr.Index, want[cr.Index], got)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"sync"
+)
+
+// RaceStress returns a Middleware that runs each test case from the given number of goroutines at once, all
+// with the same test case value, to surface data races when the tests are run with -race. The test case fails
+// if any of the runs fail or panic. With -race, the start of each test case is logged, so a report from the
+// race detector can be attributed to the test case logged before it.
+//
+//	test.Use(tbltest.RaceStress(8)).Run(func(tc testcase) bool { ... })
+func RaceStress(goroutines int) Middleware {
+	if goroutines < 1 {
+		panicf("RaceStress needs at least one goroutine, was given %v.", goroutines)
+	}
+	return func(next CaseFunc) CaseFunc {
+		return func(idx int, tc TestCase) error {
+			if raceEnabled {
+				logf("Stressing test case %v with %v goroutines.", idx, goroutines)
+			}
+			errs := make([]error, goroutines)
+			var start, wg sync.WaitGroup
+			start.Add(1)
+			wg.Add(goroutines)
+			for i := 0; i < goroutines; i++ {
+				go func(i int) {
+					defer wg.Done()
+					defer func() {
+						if rec := recover(); rec != nil {
+							errs[i] = fmt.Errorf("panicked: %v", rec)
+						}
+					}()
+					start.Wait()
+					errs[i] = next(idx, tc)
+				}(i)
+			}
+			// Release the goroutines together, so their runs overlap as much as possible.
+			start.Done()
+			wg.Wait()
+			for i, err := range errs {
+				if err != nil {
+					return fmt.Errorf("goroutine %v of %v: %v", i+1, goroutines, err)
+				}
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestRaceStress(t *testing.T) {
+	var calls int32
+	test := tbltest.Cases(1, 2)
+	test.InOrder = true
+	test.ContinueOnFailure = true
+	result := test.Use(tbltest.RaceStress(4)).RunResult(func(tc int) {
+		if atomic.AddInt32(&calls, 1) == 6 {
+			panic("boom")
+		}
+	})
+	if calls != 8 {
+		t.Errorf("expected each case to run 4 times, the test function was called %v times", calls)
+	}
+	if result.Passed != 1 || result.Failed != 1 {
+		t.Fatalf("expected one case to pass and one to fail, got %+v", result)
+	}
+	if err := result.Cases[1].Err; err == nil || !strings.Contains(err.Error(), "panicked: boom") {
+		t.Errorf("expected the panic to fail case 1, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// SubtestName returns the name RunT gives the subtest of the test case at index idx, which is what -run
+// matches. It is the name of the test case, rewritten as go test would: spaces become underscores and characters
+// that can not be printed are escaped; slashes are kept, so -run matches the parts of the name as levels of
+// subtests, as it does for a Group. If an earlier test case of the table has the same name, a suffix such as
+// #01 is added, so each subtest name selects one test case.
+func (tc *Test) SubtestName(idx int) string {
+	names := tc.subtestNames()
+	if idx < 0 || idx >= len(names) {
+		return subtestName(tc.Name(idx))
+	}
+	return names[idx]
+}
+
+// subtestNames returns the subtest names of all the test cases. They are worked out once, and again only if test
+// cases were added since, so the names SubtestName gives are the ones RunT runs the subtests as.
+func (tc *Test) subtestNames() []string {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if len(tc.subtests) != len(tc.cases) {
+		names := make([]string, len(tc.cases))
+		for i := range tc.cases {
+			names[i] = tc.cases[i].caseName(i)
+		}
+		tc.subtests = uniqueNames(names)
+	}
+	return tc.subtests
+}
+
+// uniqueNames rewrites each of the names as a subtest name, and adds a suffix to those that are taken.
+func uniqueNames(names []string) []string {
+	unique := make([]string, len(names))
+	taken := make(map[string]bool, len(names))
+	for i, name := range names {
+		name = subtestName(name)
+		for n := 1; taken[name]; n++ {
+			name = fmt.Sprintf("%v#%02d", subtestName(names[i]), n)
+		}
+		taken[name] = true
+		unique[i] = name
+	}
+	return unique
+}
+
+// subtestName rewrites name as the testing package rewrites the names given to t.Run.
+func subtestName(name string) string {
+	var b []byte
+	for _, r := range name {
+		switch {
+		case unicode.IsSpace(r):
+			b = append(b, '_')
+		case !strconv.IsPrint(r):
+			s := strconv.QuoteRune(r)
+			b = append(b, s[1:len(s)-1]...)
+		default:
+			b = append(b, string(r)...)
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,45 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestSubtestName(t *testing.T) {
+	test := tbltest.Cases(
+		tbltest.Named(0, "empty input"),
+		tbltest.Named(1, "empty_input"),
+		tbltest.Named(2, "empty input"),
+		tbltest.Named(3, "tab\there"),
+		tbltest.Named(4, "bell\a"),
+		tbltest.Group("unicode", nil, 5),
+	)
+	test.InOrder = true
+	want := []string{"empty_input", "empty_input#01", "empty_input#02", "tab_here", `bell\a`, "unicode/0"}
+	var got, ran []string
+	for i := range want {
+		got = append(got, test.SubtestName(i))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the subtest names %q, got %q", want, got)
+	}
+	test.RunT(t, func(t *testing.T, tc int) {
+		ran = append(ran, strings.TrimPrefix(t.Name(), "TestSubtestName/"))
+	})
+	if !reflect.DeepEqual(ran, want) {
+		t.Errorf("expected the subtests %q to run, got %q", want, ran)
+	}
+
+	// Adding a test case after the names were given names it too.
+	test.Add(tbltest.Named(6, "empty input"))
+	if got := test.SubtestName(6); got != "empty_input#03" {
+		t.Errorf("expected the added test case to be named %q, got %q", "empty_input#03", got)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// Suite runs a number of tables that share a fixture, such as a test database, which is set up once before
+// all of them by BeforeAll and torn down after them by AfterAll. The zero value is an empty suite.
+//
+//	var db *sql.DB
+//	suite := tbltest.Suite{
+//		BeforeAll: func() (err error) { db, err = openTestDB(); return err },
+//		AfterAll:  func() { db.Close() },
+//	}
+//	suite.Add("users", userCases, func(tc userCase) bool { ... })
+//	suite.Add("orders", orderCases, func(tc orderCase) bool { ... })
+//	if res := suite.Run(); res.Failed > 0 {
+//		t.Error(res.Report())
+//	}
+type Suite struct {
+	// BeforeAll, if not nil, is called before any of the tables are run. If it returns an error, none of them
+	// are run.
+	BeforeAll func() error
+	// AfterAll, if not nil, is called after all the tables were run, even if one of them panicked.
+	AfterAll func()
+
+	tables []suiteTable
+}
+
+// suiteTable is a table of a Suite, and the test function to run it with.
+type suiteTable struct {
+	name     string
+	test     *Test
+	function TestFunc
+}
+
+// Add adds the table to the suite, under the given name, to be run with the test function.
+func (s *Suite) Add(name string, test *Test, function TestFunc) *Suite {
+	if test == nil {
+		panicf("Suite.Add called with a nil table for %v.", name)
+	}
+	s.tables = append(s.tables, suiteTable{name: name, test: test, function: function})
+	return s
+}
+
+// TableResult is the result of one table of a suite.
+type TableResult struct {
+	// Name is the name the table was added to the suite with.
+	Name string
+	*Result
+}
+
+// SuiteResult is the result of a run of a Suite.
+type SuiteResult struct {
+	// Err is the error returned by BeforeAll, in which case no tables were run.
+	Err error
+	// Ran, Passed, Failed, Quarantined, Warned and Skipped are the totals of the tables.
+	Ran, Passed, Failed, Quarantined, Warned, Skipped int
+	// Duration is the wall clock time of the whole run, including BeforeAll and AfterAll.
+	Duration time.Duration
+	// Tables are the results of each table, in the order they were added.
+	Tables []TableResult
+}
+
+// Run checks the test functions of all the tables (see Validate), and then runs BeforeAll, each table in the
+// order it was added, and AfterAll.
+func (s *Suite) Run() *SuiteResult {
+	for _, st := range s.tables {
+		if err := st.test.Validate(st.function); err != nil {
+			panicf("table %v: %v", st.name, err)
+		}
+	}
+	start := time.Now()
+	res := SuiteResult{}
+	defer func() { res.Duration = time.Since(start) }()
+	if s.BeforeAll != nil {
+		if err := s.BeforeAll(); err != nil {
+			logf("BeforeAll failed, not running the suite: %v", err)
+			res.Err = err
+			return &res
+		}
+	}
+	if s.AfterAll != nil {
+		defer s.AfterAll()
+	}
+	for _, st := range s.tables {
+		r := st.test.run(st.function)
+		res.Tables = append(res.Tables, TableResult{Name: st.name, Result: r})
+		res.Ran += r.Ran
+		res.Passed += r.Passed
+		res.Failed += r.Failed
+		res.Quarantined += r.Quarantined
+		res.Warned += r.Warned
+		res.Skipped += r.Skipped
+	}
+	return &res
+}
+
+// Report returns a summary of the run, with a line for each table, and each test case that failed.
+func (r *SuiteResult) Report() string {
+	var buf bytes.Buffer
+	if r.Err != nil {
+		fmt.Fprintf(&buf, "The suite did not run, BeforeAll failed: %v", r.Err)
+		return buf.String()
+	}
+	fmt.Fprintf(&buf, "Ran %v test cases of %v tables in %v: %v passed, %v failed", r.Ran, len(r.Tables), r.Duration, r.Passed, r.Failed)
+	for _, t := range r.Tables {
+		fmt.Fprintf(&buf, "\n\t%v: %v test cases in %v, %v passed, %v failed", t.Name, t.Ran, t.Result.Duration, t.Passed, t.Failed)
+		for _, c := range t.Cases {
+			if c.Status == StatusFail {
+				fmt.Fprintf(&buf, "\n\t\tcase %v (%v): %v", c.Index, c.Name, c.Err)
+			}
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestSuite(t *testing.T) {
+	var calls []string
+	suite := tbltest.Suite{
+		BeforeAll: func() error {
+			calls = append(calls, "before")
+			return nil
+		},
+		AfterAll: func() { calls = append(calls, "after") },
+	}
+	ints := tbltest.Cases(1, 2, 3)
+	ints.ContinueOnFailure = true
+	suite.Add("ints", ints, func(tc int) bool {
+		calls = append(calls, "ints")
+		return tc != 2
+	})
+	suite.Add("strings", tbltest.Cases("a"), func(tc string) {
+		calls = append(calls, "strings")
+	})
+	res := suite.Run()
+	if want := []string{"before", "ints", "ints", "ints", "strings", "after"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected the calls %v, got %v", want, calls)
+	}
+	if res.Ran != 4 || res.Passed != 3 || res.Failed != 1 || len(res.Tables) != 2 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	report := res.Report()
+	for _, want := range []string{"Ran 4 test cases of 2 tables", "ints: 3 test cases", "case 1 (1): test function returned false", "strings: 1 test cases"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected the report to contain %q, got %q", want, report)
+		}
+	}
+
+	calls = nil
+	suite.BeforeAll = func() error { return errors.New("no database") }
+	if res := suite.Run(); res.Err == nil || res.Ran != 0 || len(calls) != 0 {
+		t.Errorf("expected nothing to run when BeforeAll fails, got %+v and %v", res, calls)
+	}
+}
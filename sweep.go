@@ -0,0 +1,68 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// OrderDependence is a test case whose outcome changed with the order the test cases were run in.
+type OrderDependence struct {
+	// Index is the index of the test case in the table.
+	Index int
+	// Name is the name of the test case.
+	Name string
+	// Expected is the outcome of the test case when the table was run in index order.
+	Expected Status
+	// Status is the outcome of the test case when the table was run in the order shuffled with Seed.
+	Status Status
+	// Seed is the seed of the random order; the run can be repeated with the tblTest.Seed option.
+	Seed int64
+}
+
+func (d OrderDependence) String() string {
+	return fmt.Sprintf("test case %v (%v) was %v in index order, but %v in the order shuffled with seed %v", d.Index, d.Name, d.Expected, d.Status, d.Seed)
+}
+
+// Sweep runs the table in index order, and then in n random orders, to find test cases whose outcome depends
+// on the test cases that ran before them; such as test cases that accidentally share state. All the test
+// cases are run each time, even if some fail, and each test case whose outcome in a random order differs from
+// its outcome in index order is logged and returned. Sweep does not fail the test itself.
+//
+//	if deps := test.Sweep(10, func(tc testcase) bool { ... }); len(deps) > 0 {
+//		t.Errorf("%v test cases depend on the order they run in", len(deps))
+//	}
+//
+// The random orders are shuffled with the seeds following the one given by the tblTest.Seed option.
+func (tc *Test) Sweep(n int, function TestFunc) []OrderDependence {
+	if function == nil {
+		panicf("Sweep called with a nil function.")
+	}
+	rn := tc.newRunner(function)
+	rn.continueOnFailure = true
+	if tc.checkCases() {
+		tc.last = &Result{}
+		return nil
+	}
+	expected := make(map[int]Status)
+	for _, cr := range tc.runList(rn, seq(len(tc.cases)), 0).Cases {
+		expected[cr.Index] = cr.Status
+	}
+	var deps []OrderDependence
+	base := newSeed()
+	for i := 0; i < n; i++ {
+		s := base + int64(i)
+		list := rand.New(rand.NewSource(s)).Perm(len(tc.cases))
+		for _, cr := range tc.runList(rn, list, s).Cases {
+			if want, ok := expected[cr.Index]; ok && cr.Status != want {
+				d := OrderDependence{Index: cr.Index, Name: cr.Name, Expected: want, Status: cr.Status, Seed: s}
+				logf("Order dependence: %v; use -%v=%v to reproduce.", d, flagName("Seed"), s)
+				deps = append(deps, d)
+			}
+		}
+	}
+	return deps
+}
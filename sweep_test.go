@@ -0,0 +1,47 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestSweep(t *testing.T) {
+	// Test case 1 only passes if test case 0 ran before it.
+	var ranZero bool
+	test := tbltest.Cases(0, 1, 2, 3)
+	deps := test.Sweep(20, func(tc int) bool {
+		if tc == 0 {
+			ranZero = true
+		}
+		ok := tc != 1 || ranZero
+		if tc == 3 {
+			ranZero = false
+		}
+		return ok
+	})
+	if len(deps) == 0 {
+		t.Fatalf("expected the order dependence of test case 1 to be found")
+	}
+	for _, d := range deps {
+		if d.Index != 1 || d.Expected != tbltest.StatusPass || d.Status != tbltest.StatusFail {
+			t.Errorf("unexpected order dependence: %v", d)
+		}
+	}
+
+	if deps := tbltest.Cases(0, 1, 2).Sweep(5, func(tc int) {}); len(deps) != 0 {
+		t.Errorf("expected no order dependence, got %v", deps)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "should hold 3 test cases") {
+			t.Errorf("expected Sweep to check the table like Run, got %v", r)
+		}
+	}()
+	tbltest.Cases(0, 1).ExpectCases(3).Sweep(5, func(tc int) {})
+}
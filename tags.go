@@ -0,0 +1,72 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "strings"
+
+var tags = flagString("Tags", "", "List of comma separated tags of the test cases to run. Tags starting with a '-' exclude the test cases with that tag.")
+
+// Tagged marks the test case with the given tags, so it can be selected or excluded with the tblTest.Tags option.
+//
+//	test := tbltest.Cases(
+//		testcase{...},
+//		tbltest.Tagged(testcase{...}, "slow", "network"),
+//	)
+//
+// With -tblTest.Tags=slow only the cases tagged slow are run, and with -tblTest.Tags=-network all the cases
+// except those tagged network are run.
+func Tagged(tcase TestCase, tags ...string) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.tags = append(e.tags, tags...)
+	})
+}
+
+// Tags returns the tags of the test case at the given index.
+func (tc *Test) Tags(idx int) []string {
+	if idx < 0 || idx >= len(tc.cases) {
+		return nil
+	}
+	return tc.cases[idx].tags
+}
+
+// filterTags returns the indexes in list whose test cases are selected by the tag filter. A test case is
+// selected if it has one of the included tags (or there are none) and none of the excluded ones.
+func (tc *Test) filterTags(filter string, list []int) []int {
+	var include, exclude []string
+	for _, tag := range strings.Split(filter, ",") {
+		tag = strings.TrimSpace(tag)
+		switch {
+		case tag == "" || tag == "-":
+		case tag[0] == '-':
+			exclude = append(exclude, tag[1:])
+		default:
+			include = append(include, tag)
+		}
+	}
+	var filtered []int
+	for _, idx := range list {
+		if idx < 0 || idx >= len(tc.cases) {
+			// Let the runner report the invalid index.
+			filtered = append(filtered, idx)
+			continue
+		}
+		t := tc.cases[idx].tags
+		if (len(include) == 0 || hasAnyTag(t, include)) && !hasAnyTag(t, exclude) {
+			filtered = append(filtered, idx)
+		}
+	}
+	return filtered
+}
+
+func hasAnyTag(tags []string, any []string) bool {
+	for _, tag := range tags {
+		for _, a := range any {
+			if tag == a {
+				return true
+			}
+		}
+	}
+	return false
+}
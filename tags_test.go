@@ -0,0 +1,56 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"sort"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestTags(t *testing.T) {
+	test := tbltest.Cases(
+		0,
+		tbltest.Tagged(1, "slow"),
+		tbltest.Tagged(2, "network"),
+		tbltest.Tagged(tbltest.Tagged(3, "slow"), "network"),
+	)
+	if tags := test.Tags(3); len(tags) != 2 || tags[0] != "slow" || tags[1] != "network" {
+		t.Errorf("expected test case 3 to be tagged slow and network, got %v", tags)
+	}
+
+	type testcase struct {
+		filter   string
+		expected []int
+	}
+	tests := tbltest.Cases(
+		testcase{filter: "", expected: []int{0, 1, 2, 3}},
+		testcase{filter: "slow", expected: []int{1, 3}},
+		testcase{filter: "-network", expected: []int{0, 1}},
+		testcase{filter: "slow,-network", expected: []int{1}},
+		testcase{filter: "slow,network", expected: []int{1, 2, 3}},
+	)
+	defer flag.Set("tblTest.Tags", "")
+	tests.Run(func(tc testcase) {
+		flag.Set("tblTest.Tags", tc.filter)
+		var ran []int
+		test.Run(func(v int) {
+			ran = append(ran, v)
+		})
+		sort.Ints(ran)
+		if len(ran) != len(tc.expected) {
+			t.Errorf("for %q: expected %v, got %v", tc.filter, tc.expected, ran)
+			return
+		}
+		for i := range ran {
+			if ran[i] != tc.expected[i] {
+				t.Errorf("for %q: expected %v, got %v", tc.filter, tc.expected, ran)
+				return
+			}
+		}
+	})
+}
@@ -5,29 +5,101 @@
 package tbltest
 
 import (
-	"flag"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
 	"reflect"
-	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
-var runorder = flag.String("tblTest.RunOrder", "", "List of comma separated index of the test cases to run.")
+var runorder = flagString("RunOrder", "", "List of comma separated index of the test cases to run.")
+
+var verbose = flagBool("V", false, "Log each test case as it starts and finishes.")
+
+// verboseTimeFormat is the format of the start times logged by the tblTest.V option.
+const verboseTimeFormat = "15:04:05.000"
 
 // Test holds the testcases.
 type Test struct {
-	cases []reflect.Value
+	// mu guards cases, vType and subtests, so test cases can be added from several goroutines at once.
+	mu    sync.Mutex
+	cases []entry
 	vType reflect.Type
+	// subtests are the subtest names of the test cases, kept so RunT and SubtestName agree; see subtestNames.
+	subtests []string
 	// InOrder defines weather to run the test case in the order defined or randomly.
 	// This option is overridden by the tblTest.RunOrder command line flag.
 	InOrder bool
 
 	// The order in which to run these tests. This will be overridden by the Command line flag.
 	RunOrder string
+
+	// Order is the order to run the test cases in, if neither InOrder nor RunOrder is set. The default is a
+	// random order.
+	Order Order
+
+	// ContinueOnFailure defines weather to keep running the remaining test cases after one fails, rather than
+	// stopping at the first failure. All the failures are logged at the end of the run. Without it, a test
+	// function returning false just stops the run, while the other failures are logged with how to rerun them.
+	ContinueOnFailure bool
+
+	// OnPass, OnFail and OnSkip, if not nil, are called as each test case passes, fails, or is left out of the
+	// run by the tblTest.Tags, tblTest.Quarantine or tblTest.Shard options.
+	OnPass CaseHook
+	OnFail CaseHook
+	OnSkip CaseHook
+
+	// last is the result of the last run.
+	last *Result
+	// middleware wraps each call of the test function; see Use.
+	middleware []Middleware
+	// isolate is set if each call of the test function gets a deep copy of the test case; see IsolateCases.
+	isolate bool
+	// templateData, if not nil, expands the templates in the strings of each test case; see Expand.
+	templateData map[string]interface{}
+	// rnd, if not nil, is used to shuffle the test cases; see WithRand.
+	rnd *rand.Rand
+	// events, if not nil, is given structured events of each run; see WithLogger.
+	events eventLogger
+	// logs holds what the test case that is running logged; see Logf.
+	logs caseLogs
+	// clock, if not nil, is the Clock given to test functions that take one; see WithClock.
+	clock Clock
+	// expectCases is the number of test cases the table should hold, if expectCount is set; see ExpectCases.
+	expectCases int
+	expectCount bool
+	// expectFingerprint, if not empty, is the Fingerprint the table should have; see ExpectFingerprint.
+	expectFingerprint string
+
+	// Formatter, if not nil, formats the failures of the test cases that are logged at the end of a run,
+	// instead of the formatter set with SetFormatter.
+	Formatter Formatter
+
+	// Shrink, if not nil, is used to simplify a test case that failed; it must be of the form
+	// `func (tc $testcase) []$testcase`, returning simpler variations of tc. The variations are tried
+	// in order, and the first one that still fails is shrunk in turn, until none of them fail.
+	// The smallest failing test case found is logged.
+	Shrink interface{}
+	// Oracle, if not nil, decides whether the result of the test function is right for a test case, so the
+	// expected results can be derived, such as from an inverse function or an invariant, rather than stored in
+	// each test case. It must be of the form `func (tc $testcase, got $result) error`, returning why got is
+	// wrong, and the test function must then be of the form `func (tc $testcase) $result` or
+	// `func (idx int, tc $testcase) $result`.
+	//
+	//	test.Oracle = func(tc testcase, got []byte) error {
+	//		if back := decode(got); back != tc.input {
+	//			return fmt.Errorf("round trip gave %q", back)
+	//		}
+	//		return nil
+	//	}
+	//	test.Run(func(tc testcase) []byte { return encode(tc.input) })
+	Oracle interface{}
 }
 
 // TestFunc describes a function that will do the actual testing. It must take one of four forms.
@@ -45,22 +117,91 @@ type TestFunc interface{}
 // TestCase is a custom type that describes a test case.
 type TestCase interface{}
 
+// entry is a test case in the table, along with what we know about it.
+type entry struct {
+	value reflect.Value
+	// gen, if not nil, creates the value of the test case the first time it is needed.
+	gen func() reflect.Value
+	// lazy is set if the value was created by gen. Such a test case is not named after its value, so that it
+	// has the same name before and after it is generated.
+	lazy bool
+	// name is the name of the test case, if it has one.
+	name string
+	// tags are the tags the test case was marked with.
+	tags []string
+	// expectFail is set if the test case is known to fail, for the reason given by xfailReason.
+	expectFail  bool
+	xfailReason string
+	// quarantined is set if the test case is flaky, for the reason given by quarantineReason.
+	quarantined      bool
+	quarantineReason string
+	// seed is the seed the test case was randomly generated with, if it was.
+	seed int64
+	// timeout, if not zero, is how long the test function may take for the test case; see WithTimeout.
+	timeout time.Duration
+	// blockedBy, if set, is the issue the test case is disabled by, until blockedUntil; see BlockedBy.
+	blockedBy    string
+	blockedUntil time.Time
+	// env are the environment variables set while the test case runs; see SetEnv.
+	env map[string]string
+	// skipReason, if set, is why the test case is skipped; see WithSkip.
+	skipReason string
+	// severity is how much a failure of the test case matters; see Rated.
+	severity Severity
+	// tier is how thorough the test case is; see Tiered.
+	tier Tier
+	// weight, if not zero, is how strongly the test case is favored by a random order; see Weighted.
+	weight float64
+	// source is where the test case was defined: the file and line of the call that added it, or the file it
+	// was loaded from.
+	source string
+}
+
+// caseName returns the name of the test case, which is at index idx of the table. A test case not given a name
+// with Named is named by its Name or Desc field, or its String method, if it has one; otherwise by its index.
+// Generated test cases are only named by their index, as their value is not known until they are run.
+func (e *entry) caseName(idx int) string {
+	if e.name != "" {
+		return e.name
+	}
+	if e.lazy {
+		return strconv.Itoa(idx)
+	}
+	if name := derivedName(e.value); name != "" {
+		return name
+	}
+	return strconv.Itoa(idx)
+}
+
+// generated returns the test case, or nil if it has not been generated yet.
+func (e *entry) generated() TestCase {
+	if e.gen != nil || !e.value.IsValid() {
+		return nil
+	}
+	return e.value.Interface()
+}
+
+// get returns the value of the test case, generating it if needed.
+func (e *entry) get() reflect.Value {
+	if e.gen != nil {
+		e.value = e.gen()
+		e.gen = nil
+	}
+	return e.value
+}
+
 func panicf(format string, vals ...interface{}) {
-	pc, _, _, ok := runtime.Caller(2)
-	details := runtime.FuncForPC(pc)
 	var callSite string
-	if ok && details != nil {
-		callSite = fmt.Sprintf("Called from %v: ", details.Name())
+	if name, ok := outsideCaller(); ok {
+		callSite = fmt.Sprintf("Called from %v: ", name)
 	}
 	panic(fmt.Sprintf(callSite+format, vals...))
 }
 
 func logf(format string, vals ...interface{}) {
-	pc, _, _, ok := runtime.Caller(2)
-	details := runtime.FuncForPC(pc)
 	var callSite string
-	if ok && details != nil {
-		callSite = fmt.Sprintf("Called from %v: ", details.Name())
+	if name, ok := outsideCaller(); ok {
+		callSite = fmt.Sprintf("Called from %v: ", name)
 	}
 	log.Printf(callSite+format, vals...)
 }
@@ -80,22 +221,51 @@ func runOrder(runorder string) (idx []int, ok bool) {
 //   The test cases can be any type, as long as they are all the same.
 func Cases(testcases ...TestCase) *Test {
 	tc := Test{}
+	if err := tc.addCases(callerFileLine(), testcases); err != nil {
+		panicf("%v", err)
+	}
+	return &tc
+}
+
+// TryCases is like Cases, but returns an error rather than panicking if the test cases are not valid, or not
+// all of the same type.
+func TryCases(testcases ...TestCase) (*Test, error) {
+	tc := Test{}
+	if err := tc.addCases(callerFileLine(), testcases); err != nil {
+		return nil, err
+	}
+	return &tc, nil
+}
+
+// addCases adds the test cases, defined at source, to the table. None are added if any of them are not valid.
+func (tc *Test) addCases(source string, testcases []TestCase) error {
+	testcases = flatten(testcases)
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	vType := tc.vType
+	entries := make([]entry, 0, len(testcases))
 	for i, tcase := range testcases {
-		val := reflect.ValueOf(tcase)
+		e := newEntry(tcase)
+		if e.source == "" {
+			e.source = source
+		}
+		val := e.value
 		if val.Kind() == reflect.Invalid {
-			panicf("Testcase %v is not a valid test case.", i)
+			return fmt.Errorf("Testcase %v is not a valid test case.", i)
 		}
 		// The first element determines that type of the rest of the elements.
-		if tc.vType == nil {
-			tc.vType = val.Type()
+		if vType == nil {
+			vType = val.Type()
 		} else {
-			if val.Type() != tc.vType {
-				panicf("Testcases should be of type %v, but element %v is of type %v.", tc.vType, i, val.Type())
+			if val.Type() != vType {
+				return fmt.Errorf("Testcases should be of type %v, but element %v is of type %v.", vType, i, val.Type())
 			}
 		}
-		tc.cases = append(tc.cases, val)
+		entries = append(entries, e)
 	}
-	return &tc
+	tc.vType = vType
+	tc.cases = append(tc.cases, entries...)
+	return nil
 }
 
 func runTest(fn reflect.Value, idx int, testcase reflect.Value, tp bool, r bool) bool {
@@ -111,19 +281,262 @@ func runTest(fn reflect.Value, idx int, testcase reflect.Value, tp bool, r bool)
 	return true
 }
 
-func runTests(list []int, fn reflect.Value, cases []reflect.Value, tp bool, r bool) int {
-	count := 0
-	for _, idx := range list {
+// runner runs the test function over the test cases of a table.
+type runner struct {
+	fn     reflect.Value
+	tp     bool // the test function takes the index of the test case.
+	r      bool // the test function returns a bool.
+	shrink reflect.Value
+	// reportQuarantined is set if the failures of quarantined test cases should only be reported.
+	reportQuarantined bool
+	// continueOnFailure is set if the run should go on after a test case fails.
+	continueOnFailure bool
+	onPass            CaseHook
+	onFail            CaseHook
+	// call runs a test case through the middleware, down to the test function.
+	call CaseFunc
+	// vType is the type of the test cases.
+	vType reflect.Type
+	// seed is the seed the test cases were shuffled with, or zero if they were not.
+	seed int64
+	// deadline, if not zero, is when to stop starting test cases.
+	deadline time.Time
+	// formatter formats the failures that are logged at the end of the run.
+	formatter Formatter
+	// randSeed, if not nil, returns the seed of the random sources given to the test function; see injectFunc.
+	randSeed func() int64
+	// failOn is the least severity of the test cases whose failures fail the run; see tblTest.FailOn.
+	failOn Severity
+	// events, if not nil, is given the events of the run of the named test.
+	events eventLogger
+	test   string
+	// isolate is set if the test function is given a deep copy of the test case.
+	isolate bool
+	// templateData, if not nil, is what the templates in the strings of the test case are expanded with.
+	templateData map[string]interface{}
+	// fast, if not nil, calls the test function without reflection.
+	fast func(idx int, tc TestCase) (passed, ok bool)
+	// check, if not nil, is called with the test case instead of the test function.
+	check CaseFunc
+	// plan, if not nil, is given the test cases that are going to be run, in order, before the run starts.
+	plan func(list []int)
+	// envByT is set if the environment variables of the test cases are set by RunT, with t.Setenv, instead.
+	envByT bool
+	// blocked, if not nil, is told of the test cases that fail without being run as their BlockedBy expired.
+	blocked func(idx int, err error)
+	// logs holds what each test case logged with Logf.
+	logs *caseLogs
+}
+
+// prepare returns the test case as the test function is given it: with its templates expanded, or deep
+// copied.
+func (rn *runner) prepare(tcase TestCase) (TestCase, error) {
+	if rn.templateData != nil {
+		return expandCase(tcase, rn.templateData)
+	}
+	if rn.isolate {
+		if v := reflect.ValueOf(tcase); v.IsValid() {
+			return deepCopy(v, make(map[copyKey]reflect.Value)).Interface(), nil
+		}
+	}
+	return tcase, nil
+}
+
+// invoke calls the test function with the test case. It is the innermost CaseFunc of the middleware chain.
+func (rn *runner) invoke(idx int, tcase TestCase) error {
+	tcase, err := rn.prepare(tcase)
+	if err != nil {
+		return err
+	}
+	if rn.check != nil {
+		return rn.check(idx, tcase)
+	}
+	if rn.fast != nil {
+		if passed, ok := rn.fast(idx, tcase); ok {
+			if !passed {
+				return errReturnedFalse
+			}
+			return nil
+		}
+	}
+	testcase := reflect.ValueOf(tcase)
+	if !testcase.IsValid() {
+		testcase = reflect.Zero(rn.vType)
+	}
+	if !testcase.Type().AssignableTo(rn.vType) {
+		panicf("Middleware passed a test case of type %v, expected it to be %v", testcase.Type(), rn.vType)
+	}
+	if !runTest(rn.fn, idx, testcase, rn.tp, rn.r) {
+		return errReturnedFalse
+	}
+	return nil
+}
+
+// runTests runs the test cases in list. If the run ran out of time, notRun are the test cases it did not get
+// to.
+func (rn *runner) runTests(list []int, cases []entry) (results []CaseResult, notRun []int) {
+	var quarantinedFailures, warnedFailures []int
+	// generated is set if a failed test case was randomly generated.
+	var generated bool
+	prog := startProgress(*progress, len(list))
+	defer prog.stop()
+	defer rn.trackDone()
+	for i, idx := range list {
+		if !rn.deadline.IsZero() && time.Now().After(rn.deadline) {
+			notRun = list[i:]
+			logf("The %v budget of %v ran out; %v test cases were not run: %v", *maxDuration, flagName("MaxDuration"), len(notRun), summarizeIndexes(notRun))
+			break
+		}
 		if idx < 0 || idx >= len(cases) {
 			logf("Encountered invalid index %v, skipping.", idx)
 			continue
 		}
-		count++
-		if !runTest(fn, idx, cases[idx], tp, r) {
+		e := &cases[idx]
+		name := e.caseName(idx)
+		prog.start(idx, name)
+		start := time.Now()
+		if *verbose {
+			logf("Running test case %v (%v), started at %v", idx, name, start.Format(verboseTimeFormat))
+		}
+		if rn.events != nil {
+			rn.events.caseStarted(rn.test, idx, name)
+		}
+		rn.trackStart(idx, name, start)
+		stop := watch(*watchdog, idx, name, start)
+		status, cont, err := rn.runCase(idx, e)
+		stop()
+		rn.trackFinish()
+		duration := time.Since(start)
+		if *verbose {
+			logf("Finished test case %v (%v) in %v, status: %v", idx, name, duration, status)
+		}
+		prog.finish(!status.failed())
+		switch status {
+		case StatusQuarantined:
+			quarantinedFailures = append(quarantinedFailures, idx)
+		case StatusWarn:
+			warnedFailures = append(warnedFailures, idx)
+		}
+		rn.notify(idx, name, e, status, err)
+		if exporter := spanExporter(); exporter != nil {
+			exporter.ExportSpan(CaseSpan{
+				Test:     callerTestName(),
+				Index:    idx,
+				Name:     name,
+				Tags:     e.tags,
+				Status:   status,
+				Err:      err,
+				Start:    start,
+				Duration: duration,
+			})
+		}
+		var repro string
+		if status.failed() {
+			seed := e.seed
+			if seed == 0 && rn.randSeed != nil {
+				// The test function's random choices depend on the seed too.
+				seed = rn.randSeed()
+			}
+			repro = reproCommand(callerTestName(), idx, seed)
+			if e.seed != 0 {
+				// The test cases will be generated from the seed, so the order can't be reproduced too.
+				generated = true
+			}
+		}
+		results = append(results, CaseResult{
+			Index:    idx,
+			Name:     name,
+			Status:   status,
+			Err:      err,
+			Duration: duration,
+			Source:   e.source,
+			Repro:    repro,
+		})
+		if rn.events != nil {
+			rn.events.caseFinished(rn.test, results[len(results)-1])
+		}
+		if status == StatusFail && !rn.continueOnFailure && !returnedFalse(err) {
+			// Returning false stops the run quietly; other failures say how to rerun the test case.
+			logf("%v: %v; to rerun it: %v", colorize(ansiBold+ansiRed, fmt.Sprintf("Test case %v (%v) failed", idx, name)), errMessage(err), repro)
+		}
+		if !cont && !rn.continueOnFailure {
 			break
 		}
 	}
-	return count
+	if len(quarantinedFailures) > 0 {
+		logf("%v quarantined test cases failed: %v", len(quarantinedFailures), quarantinedFailures)
+	}
+	if len(warnedFailures) > 0 {
+		logf("Warning: %v test cases less severe than -%v=%v failed: %v", len(warnedFailures), flagName("FailOn"), *failOn, summarizeIndexes(warnedFailures))
+	}
+	if rn.continueOnFailure {
+		logFailures(rn.formatter, results)
+	}
+	if rn.seed != 0 && !generated && hasFailures(results) {
+		logf("The test cases ran in a random order; to run them in the same order again: %v", orderCommand(callerTestName(), rn.seed))
+	}
+	return results, notRun
+}
+
+// errReturnedFalse is the error recorded for a test case whose test function returned false.
+var errReturnedFalse = errors.New("test function returned false")
+
+// returnedFalse reports whether err is the failure of a test function that returned false.
+func returnedFalse(err error) bool {
+	if oe, ok := err.(outputError); ok {
+		err = oe.err
+	}
+	return err == errReturnedFalse
+}
+
+// runCase runs a single test case, and reports its status, if the run should continue, and why it failed.
+func (rn *runner) runCase(idx int, e *entry) (status Status, cont bool, err error) {
+	if e.blockedBy != "" {
+		err := blockedError(e)
+		if rn.blocked != nil {
+			rn.blocked(idx, err)
+		}
+		return StatusFail, true, err
+	}
+	call := rn.call
+	if e.timeout > 0 {
+		call = withTimeout(call, e.timeout)
+	}
+	if !rn.envByT {
+		if env := e.environment(); len(env) > 0 {
+			defer setEnv(env)()
+		}
+	}
+	if rn.logs != nil {
+		rn.logs.start(idx, e.caseName(idx))
+		defer func() {
+			if logged := rn.logs.stop(); err != nil && logged != "" {
+				err = outputError{err: err, label: "log", output: logged}
+			}
+		}()
+	}
+	switch {
+	case e.quarantined && rn.reportQuarantined:
+		if err := runQuarantined(call, idx, e); err != nil {
+			return StatusQuarantined, true, err
+		}
+		return StatusPass, true, nil
+	case e.expectFail:
+		if err := runExpectedFailure(call, idx, e); err != nil {
+			return StatusFail, false, err
+		}
+		return StatusXFail, true, nil
+	}
+	if err := call(idx, e.get().Interface()); err != nil {
+		if rn.shrink.IsValid() {
+			shrinkCase(rn.shrink, call, idx, e.get())
+		}
+		if e.warnOnly(rn.failOn) {
+			return StatusWarn, true, err
+		}
+		return StatusFail, false, err
+	}
+	return StatusPass, true, nil
 }
 
 func seq(n int) (idxs []int) {
@@ -144,92 +557,395 @@ func seq(n int) (idxs []int) {
 //
 //    *  `func (idx int, tc $testcase) bool`
 //
+// The function may take a Clock (see WithClock), or a *rand.Rand seeded for the test case, in place of the index
+// of the test case.
+// If the Oracle is set, the function returns a result for the Oracle to check instead.
 func (tc *Test) Run(function TestFunc) int {
 
 	if function == nil {
 		fmt.Fprintf(os.Stderr, "WARNING: on %v : Run called with nil function, skipping", MyCallerFileLine())
 		return 0
 	}
+	return tc.run(function).Ran
+}
 
-	fn := reflect.ValueOf(function)
-	fnType := fn.Type()
+// RunResult is like Run, but returns the Result of the run rather than just the number of test cases run.
+func (tc *Test) RunResult(function TestFunc) *Result {
 
-	if fnType.Kind() != reflect.Func {
-		panicf("Was not provided a function.")
+	if function == nil {
+		fmt.Fprintf(os.Stderr, "WARNING: on %v : RunResult called with nil function, skipping", MyCallerFileLine())
+		return &Result{}
+	}
+	return tc.run(function)
+}
+
+// Validate checks that the test function is of one of the forms accepted by Run for the test cases of the
+// table, that the Shrink and Oracle functions, if set, are valid, that the table holds the number of test cases
+// given to ExpectCases with the fingerprint given to ExpectFingerprint, and that the test cases set their
+// required fields (see ValidateCases); without running anything. Run panics for the problems Validate returns as errors.
+func (tc *Test) Validate(function TestFunc) error {
+	if function == nil {
+		return errors.New("was given a nil test function")
+	}
+	function, _ = tc.injectFunc(function)
+	if tc.Oracle != nil {
+		if _, _, err := tc.checkOracle(reflect.ValueOf(function)); err != nil {
+			return err
+		}
+	} else if _, _, err := tc.checkFunc(reflect.ValueOf(function)); err != nil {
+		return err
+	}
+	if tc.Shrink != nil {
+		if _, err := tc.checkShrinkFunc(); err != nil {
+			return err
+		}
+	}
+	if err := tc.checkExpected(); err != nil {
+		return err
+	}
+	return tc.ValidateCases()
+}
+
+// checkFunc checks the parameters of the test function, returning whether it takes the index of the test
+// case, and whether it returns a bool.
+func (tc *Test) checkFunc(fn reflect.Value) (twoInParams, hasOutParam bool, err error) {
+	if fn.Kind() != reflect.Func {
+		return false, false, errors.New("Was not provided a function.")
 	}
-	// Check the parameters.
-	var twoInParams bool
-	var hasOutParam bool
+	fnType := fn.Type()
 	switch fnType.NumIn() {
 	// If there is only one parameter then it should of the test case type.
 	case 1:
 		if fnType.In(0) != tc.vType {
-			panicf("Incorrect parameter for test function given. Was given %v, expected it to be %v", fnType.In(0), tc.vType)
+			return false, false, fmt.Errorf("Incorrect parameter for test function given. Was given %v, expected it to be %v", fnType.In(0), tc.vType)
 		}
 	case 2:
 		if fnType.In(0) != reflect.TypeOf(int(1)) {
-			panicf("Incorrect parameter one for test function given. Was given %v, expected it to be int", fnType.In(0))
+			return false, false, fmt.Errorf("Incorrect parameter one for test function given. Was given %v, expected it to be int", fnType.In(0))
 		}
 		if fnType.In(1) != tc.vType {
-			panicf("Incorrect parameter two for test function given. Was given %v, expected it to be %v", fnType.In(0), tc.vType)
+			return false, false, fmt.Errorf("Incorrect parameter two for test function given. Was given %v, expected it to be %v", fnType.In(1), tc.vType)
 		}
 		twoInParams = true
 	default:
-		panicf("Incorrect number of parameters given. Expect function to take one of two forms. func(idx int, testcase $T) or func(testcase $T)")
+		return false, false, errors.New("Incorrect number of parameters given. Expect function to take one of two forms. func(idx int, testcase $T) or func(testcase $T)")
 	}
 	switch fnType.NumOut() {
 	case 0:
 	// Nothing to do.
 	case 1:
 		if fnType.Out(0) != reflect.TypeOf(true) {
-			panicf("Expected out parameter of test function to be a boolean. Was given %v", fnType.Out(0))
+			return false, false, fmt.Errorf("Expected out parameter of test function to be a boolean. Was given %v", fnType.Out(0))
 		}
 		hasOutParam = true
 	default:
-		panicf("Expected there to be not out parameters or a boolean out parameter to test function.")
+		return false, false, errors.New("Expected there to be not out parameters or a boolean out parameter to test function.")
+	}
+	return twoInParams, hasOutParam, nil
+}
+
+// TryRun is like Run, but returns an error rather than panicking if the test function, or the Shrink function,
+// is not valid for the test cases of the table, the test cases are missing required fields, or the table does
+// not hold the number of test cases given to ExpectCases (see Validate).
+func (tc *Test) TryRun(function TestFunc) (int, error) {
+	if err := tc.Validate(function); err != nil {
+		return 0, err
+	}
+	return tc.run(function).Ran, nil
+}
+
+func (tc *Test) run(function TestFunc) *Result {
+	return tc.runWith(tc.newRunner(function))
+}
+
+// runWith runs the table with the runner.
+func (tc *Test) runWith(rn *runner) *Result {
+	if tc.checkCases() {
+		tc.last = &Result{}
+		return tc.last
+	}
+	list, seed := tc.runOrder()
+	return tc.runList(rn, list, seed)
+}
+
+// checkCases checks the test cases before a run: that there are as many as expected, and that they are valid.
+// It reports if the table is empty, so there is nothing to run.
+func (tc *Test) checkCases() (empty bool) {
+	if err := tc.checkExpected(); err != nil {
+		panicf("%v", err)
 	}
 	if len(tc.cases) == 0 {
-		return 0
+		return true
+	}
+	if err := tc.ValidateCases(); err != nil {
+		panicf("%v", err)
+	}
+	if *warnDuplicates {
+		tc.CheckUnique()
+	}
+	return false
+}
+
+// newRunner returns a runner for the test function, after checking it is valid for the table.
+func (tc *Test) newRunner(function TestFunc) *runner {
+	function, randSeed := tc.injectFunc(function)
+	fn := reflect.ValueOf(function)
+	if tc.Oracle != nil {
+		rn := tc.newOracleRunner(fn)
+		rn.randSeed = randSeed
+		return rn
+	}
+	twoInParams, hasOutParam, err := tc.checkFunc(fn)
+	if err != nil {
+		panicf("%v", err)
+	}
+	rn := tc.newCheckRunner(nil)
+	rn.fn, rn.tp, rn.r = fn, twoInParams, hasOutParam
+	rn.fast = fastFunc(function)
+	rn.randSeed = randSeed
+	return rn
+}
+
+// newCheckRunner returns a runner that calls check with each test case, rather than a test function.
+func (tc *Test) newCheckRunner(check CaseFunc) *runner {
+	rn := runner{
+		continueOnFailure: tc.ContinueOnFailure,
+		onPass:            tc.OnPass,
+		onFail:            tc.OnFail,
+		vType:             tc.vType,
+		formatter:         tc.Formatter,
+		failOn:            failOnSeverity(),
+		isolate:           tc.isolate,
+		templateData:      tc.templateData,
+		check:             check,
+		logs:              &tc.logs,
+	}
+	if rn.formatter == nil {
+		rn.formatter = defaultFormatter()
+	}
+	rn.call = tc.tracing(chain(rn.invoke, tc.middleware))
+	if *profileCaseName != "" {
+		rn.call = tc.profiling(rn.call)
+	}
+	if tc.Shrink != nil {
+		rn.shrink = tc.shrinkFunc()
+	}
+	return &rn
+}
+
+// runList runs the test cases in list, in that order, leaving out the ones filtered by the command line
+// options. seed is the seed that list was shuffled with, if any.
+func (tc *Test) runList(rn *runner, list []int, seed int64) *Result {
+	start := time.Now()
+	if *tableNames != "" {
+		filtered := tc.filterTable(list)
+		tc.skip(list, filtered, "not a table selected by the "+flagName("Table")+" option")
+		list = filtered
+	}
+	if *rerunFailed {
+		filtered := tc.filterFailed(list)
+		tc.skip(list, filtered, "passed in the last run")
+		list = filtered
+	}
+	list = tc.filterSkipped(list)
+	list = tc.filterBlocked(list, start)
+	if short() {
+		filtered := tc.filterTier(list)
+		tc.skip(list, filtered, "in the full tier, which is not run with -test.short")
+		list = filtered
+	}
+	if *tags != "" {
+		filtered := tc.filterTags(*tags, list)
+		tc.skip(list, filtered, "excluded by the "+flagName("Tags")+" option")
+		list = filtered
+	}
+	switch quarantineMode() {
+	case quarantineSkip:
+		filtered := tc.filterQuarantined(list)
+		tc.skip(list, filtered, "quarantined")
+		list = filtered
+	case quarantineReport:
+		rn.reportQuarantined = true
+	}
+	if *shard != "" {
+		filtered := filterShard(*shard, list)
+		if *shardTimings != "" {
+			filtered = tc.filterShardByTime(*shard, list)
+		}
+		tc.skip(list, filtered, "in another shard")
+		list = filtered
+	}
+	if *sample > 0 {
+		filtered := tc.sampleCases(list, *sample, seed)
+		tc.skip(list, filtered, "not chosen by the "+flagName("Sample")+" option")
+		list = filtered
 	}
 	// Now loop through the test cases and call the test function, check to see if we should stop or keep going.
-	return runTests(tc.runOrder(), fn, tc.cases, twoInParams, hasOutParam)
+	if *listCases {
+		tc.list(os.Stdout, list)
+		tc.last = newResult(len(tc.cases), nil, seed, time.Since(start), nil)
+		return tc.last
+	}
+	rn.seed = seed
+	if *maxDuration > 0 {
+		rn.deadline = start.Add(*maxDuration)
+	}
+	if tc.events != nil {
+		rn.events = tc.events
+		rn.test = callerTestName()
+		rn.events.runStarted(rn.test, len(list), seed)
+	}
+	if rn.plan != nil {
+		rn.plan(list)
+	}
+	results, notRun := rn.runTests(list, tc.cases)
+	tc.skip(notRun, nil, "the "+flagName("MaxDuration")+" budget ran out")
+	tc.last = newResult(len(tc.cases), list, seed, time.Since(start), results)
+	if rn.events != nil {
+		rn.events.runFinished(rn.test, tc.last)
+	}
+	recordRun(tc.tableKey(), results, seed, list)
+	tc.recordFailures(results)
+	if *slowest > 0 {
+		logf("%v", tc.Report())
+	}
+	return tc.last
 }
 
 // AddCases takes a list of test cases to use for the table driven tests. It is added to the current list of tests.
 //   The test cases can be any type, as long as they are ALL the tests are of the same type, this included any tests declared
 // in the Cases methods to create the test object.
+// AddCases, Add, AddRandom and Merge may be called from several goroutines at once, such as from workers
+// generating test cases in parallel, along with Len, Case and Name, but not while the table is running.
 func (tc *Test) AddCases(testcases ...TestCase) {
-	for i, tcase := range testcases {
-		val := reflect.ValueOf(tcase)
-		if val.Kind() == reflect.Invalid {
-			panicf("Testcase %v is not a valid test case.", i)
-		}
-		// The first element determines that type of the rest of the elements.
-		if tc.vType == nil {
-			tc.vType = val.Type()
-		} else {
-			if val.Type() != tc.vType {
-				panicf("Testcases should be of type %v, but element %v is of type %v.", tc.vType, i, val.Type())
-			}
-		}
-		tc.cases = append(tc.cases, val)
+	if err := tc.addCases(callerFileLine(), testcases); err != nil {
+		panicf("%v", err)
 	}
 }
 
-func (tc *Test) runOrder() []int {
+// Add is like AddCases, but returns the Test so calls can be chained. This makes it easy to add cases from
+// several places, for example from files with different build tags:
+//
+//	func init() {
+//		tests.Add(
+//			testcase{...},
+//		)
+//	}
+func (tc *Test) Add(testcases ...TestCase) *Test {
+	tc.AddCases(testcases...)
+	return tc
+}
+
+// Merge adds the test cases of other to the table, keeping their names. The test cases of both tables must be
+// of the same type.
+func (tc *Test) Merge(other *Test) *Test {
+	if other == nil {
+		return tc
+	}
+	other.mu.Lock()
+	vType, cases := other.vType, other.cases[:len(other.cases):len(other.cases)]
+	other.mu.Unlock()
+	if vType == nil {
+		return tc
+	}
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.vType == nil {
+		tc.vType = vType
+	} else if vType != tc.vType {
+		panicf("Testcases should be of type %v, but the merged table has testcases of type %v.", tc.vType, vType)
+	}
+	tc.cases = append(tc.cases, cases...)
+	return tc
+}
+
+// Len returns the number of test cases in the table.
+func (tc *Test) Len() int {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return len(tc.cases)
+}
+
+// Case returns the test case at the given index; generating it if needed.
+func (tc *Test) Case(idx int) TestCase {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if idx < 0 || idx >= len(tc.cases) {
+		panicf("Index %v is out of range, the table has %v test cases.", idx, len(tc.cases))
+	}
+	return tc.cases[idx].get().Interface()
+}
+
+// Name returns the name of the test case at the given index. Test cases that were not given a name
+// are named after their index.
+func (tc *Test) Name(idx int) string {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if idx >= 0 && idx < len(tc.cases) {
+		return tc.cases[idx].caseName(idx)
+	}
+	return strconv.Itoa(idx)
+}
+
+// runOrder returns the indexes of the test cases in the order they should run. If the order is random, the
+// seed used to shuffle them is returned as well.
+func (tc *Test) runOrder() (idxs []int, seed int64) {
 
 	if runorder != nil && *runorder != "" {
 		if idxs, ok := runOrder(*runorder); ok {
-			return idxs
+			return idxs, 0
 		}
 	}
 	if tc.RunOrder != "" {
 		if idxs, ok := runOrder(tc.RunOrder); ok {
-			return idxs
+			return idxs, 0
 		}
 	}
 	if tc.InOrder {
-		return seq(len(tc.cases))
+		return seq(len(tc.cases)), 0
+	}
+	switch tc.Order {
+	case OrderIndex:
+		return seq(len(tc.cases)), 0
+	case OrderReverse:
+		idxs = seq(len(tc.cases))
+		for i, j := 0, len(idxs)-1; i < j; i, j = i+1, j-1 {
+			idxs[i], idxs[j] = idxs[j], idxs[i]
+		}
+		return idxs, 0
+	case OrderByName:
+		byName := casesByName{idxs: seq(len(tc.cases)), cases: tc.cases}
+		sort.Stable(byName)
+		return byName.idxs, 0
+	case OrderFailedFirst:
+		failed := tc.filterFailed(seq(len(tc.cases)))
+		idxs = append(idxs, failed...)
+		for i, j := 0, 0; i < len(tc.cases); i++ {
+			if j < len(failed) && failed[j] == i {
+				j++
+				continue
+			}
+			idxs = append(idxs, i)
+		}
+		return idxs, 0
 	}
-	return rand.Perm(len(tc.cases))
+	r := tc.rnd
+	if r == nil {
+		seed = newSeed()
+		r = rand.New(rand.NewSource(seed))
+	}
+	if tc.weighted() {
+		return tc.weightedPerm(r, seq(len(tc.cases))), seed
+	}
+	return r.Perm(len(tc.cases)), seed
+}
+
+// WithRand sets the source of randomness used to shuffle the test cases, when they are run in a random order,
+// instead of one seeded by the tblTest.Seed option. As a *rand.Rand is not safe for concurrent use, it should
+// not be shared with tables run in parallel.
+//
+//	test.WithRand(rand.New(rand.NewSource(1))).Run(func(tc testcase) { ... })
+func (tc *Test) WithRand(r *rand.Rand) *Test {
+	tc.rnd = r
+	return tc
 }
@@ -5,6 +5,11 @@
 package tbltest_test
 
 import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/gdey/tbltest"
@@ -71,6 +76,66 @@ func TestIntCases(t *testing.T) {
 	}
 }
 
+func TestAdd(t *testing.T) {
+	test := tbltest.Cases(0).Add(1, 2).Add(3)
+	test.InOrder = true
+	count := test.Run(func(idx int, tc int) {
+		if tc != idx {
+			t.Errorf("for test %v: expected %[1]v, got %v", idx, tc)
+		}
+	})
+	if count != 4 {
+		t.Errorf("did not run all the testcases.")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	baseline := tbltest.Cases(0, 1)
+	test := tbltest.Cases(2).Merge(baseline).Merge(tbltest.Cases())
+	if count := test.Run(func(tc int) {}); count != 3 {
+		t.Errorf("expected to run 3 tests, ran %v instead", count)
+	}
+	if count := baseline.Run(func(tc int) {}); count != 2 {
+		t.Errorf("expected merging to leave the other table alone, it ran %v tests", count)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected merging tables of different types to panic.")
+		}
+	}()
+	test.Merge(tbltest.Cases("zero"))
+}
+
+func TestLenAndCase(t *testing.T) {
+	test := tbltest.Cases("a", "b", "c")
+	if test.Len() != 3 {
+		t.Errorf("expected 3 test cases, got %v", test.Len())
+	}
+	if tc := test.Case(1); tc != "b" {
+		t.Errorf("expected test case 1 to be %q, got %v", "b", tc)
+	}
+	if test.Name(1) != "1" {
+		t.Errorf("expected an unnamed test case to be named after its index, got %q", test.Name(1))
+	}
+}
+
+func TestVerbose(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	flag.Set("tblTest.V", "true")
+	defer flag.Set("tblTest.V", "false")
+
+	test := tbltest.Cases(0, 1)
+	test.Run(func(tc int) {})
+	for _, expected := range []string{"Running test case 0 (0)", "Finished test case 1 (1)"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %q to be logged, got log: %v", expected, buf.String())
+		}
+	}
+}
+
 func TestNilFunc(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -81,3 +146,53 @@ func TestNilFunc(t *testing.T) {
 	test := tbltest.Cases(testcase{}, testcase{})
 	test.Run(nil)
 }
+
+func TestValidate(t *testing.T) {
+	test := tbltest.Cases(1, 2)
+	for _, fn := range []tbltest.TestFunc{
+		func(tc int) {},
+		func(tc int) bool { return true },
+		func(idx, tc int) {},
+		func(idx, tc int) bool { return true },
+	} {
+		if err := test.Validate(fn); err != nil {
+			t.Errorf("expected %T to be valid, got %v", fn, err)
+		}
+	}
+	for _, fn := range []tbltest.TestFunc{
+		nil,
+		1,
+		func(tc string) {},
+		func(idx string, tc int) {},
+		func(tc int) int { return 0 },
+		func(a, b, c int) {},
+	} {
+		if err := test.Validate(fn); err == nil {
+			t.Errorf("expected %T to be invalid", fn)
+		}
+	}
+	test.Shrink = func(tc string) []string { return nil }
+	if err := test.Validate(func(tc int) {}); err == nil {
+		t.Errorf("expected the Shrink function to be invalid")
+	}
+}
+
+func TestTryCases(t *testing.T) {
+	if _, err := tbltest.TryCases(1, "2"); err == nil {
+		t.Errorf("expected an error for test cases of different types")
+	}
+	if _, err := tbltest.TryCases(1, nil); err == nil {
+		t.Errorf("expected an error for a nil test case")
+	}
+	test, err := tbltest.TryCases(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := test.TryRun(func(tc string) {}); err == nil {
+		t.Errorf("expected an error for a test function of the wrong type")
+	}
+	ran, err := test.TryRun(func(tc int) {})
+	if err != nil || ran != 2 {
+		t.Errorf("expected 2 test cases to run, got %v, %v", ran, err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// Expand makes the table expand the text/template placeholders in the strings of each test case with data,
+// just before the test case is passed to the test function, so test cases loaded from files need not hard
+// code the paths or ports of the machine they run on:
+//
+//	// testdata/cases.json: [{"Addr": "localhost:{{.Port}}", "Config": "{{.TmpDir}}/config.yaml"}]
+//	test.Expand(map[string]interface{}{"Port": port, "TmpDir": dir})
+//
+// Every string in the test case is expanded, including those in unexported fields, slices, maps and the values
+// pointed to; the test function is given an expanded deep copy of the test case (see IsolateCases), leaving
+// the table as it was. A placeholder that is not in data, or a template that does not parse, fails the test
+// case.
+func (tc *Test) Expand(data map[string]interface{}) *Test {
+	tc.templateData = data
+	return tc
+}
+
+// expandCase returns a deep copy of tcase with the templates in its strings expanded with data.
+func expandCase(tcase TestCase, data map[string]interface{}) (TestCase, error) {
+	v := reflect.ValueOf(tcase)
+	if !v.IsValid() {
+		return tcase, nil
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(deepCopy(v, make(map[copyKey]reflect.Value)))
+	if err := expandValue(c, "", data, make(map[uintptr]bool)); err != nil {
+		return nil, err
+	}
+	return c.Interface(), nil
+}
+
+// expandValue expands the templates in the strings of v, which must be settable. path is the path to v from
+// the test case, for the errors; expanded are the pointers already visited.
+func expandValue(v reflect.Value, path string, data map[string]interface{}, expanded map[uintptr]bool) error {
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if !strings.Contains(s, "{{") {
+			return nil
+		}
+		t, err := template.New(path).Option("missingkey=error").Parse(s)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return err
+		}
+		v.SetString(buf.String())
+	case reflect.Ptr:
+		if v.IsNil() || expanded[v.Pointer()] {
+			return nil
+		}
+		expanded[v.Pointer()] = true
+		return expandValue(v.Elem(), path, data, expanded)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		c := reflect.New(v.Elem().Type()).Elem()
+		c.Set(v.Elem())
+		if err := expandValue(c, path, data, expanded); err != nil {
+			return err
+		}
+		v.Set(c)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := settableField(v, i)
+			if err := expandValue(f, path+"."+v.Type().Field(i).Name, data, expanded); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := expandValue(v.Index(i), fmt.Sprintf("%v[%v]", path, i), data, expanded); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			c := reflect.New(v.Type().Elem()).Elem()
+			c.Set(v.MapIndex(k))
+			if err := expandValue(c, fmt.Sprintf("%v[%v]", path, k), data, expanded); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, c)
+		}
+	}
+	return nil
+}
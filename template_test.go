@@ -0,0 +1,43 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestExpand(t *testing.T) {
+	type testcase struct {
+		Addr  string
+		args  []string
+		env   map[string]string
+		Count int
+	}
+	original := testcase{
+		Addr: "localhost:{{.Port}}",
+		args: []string{"-config", "{{.TmpDir}}/config.yaml"},
+		env:  map[string]string{"HOME": "{{.TmpDir}}"},
+	}
+	test := tbltest.Cases(original).Expand(map[string]interface{}{"Port": 8080, "TmpDir": "/tmp/x"})
+	var got testcase
+	if count := test.Run(func(tc testcase) { got = tc }); count != 1 {
+		t.Fatalf("expected the test case to pass")
+	}
+	if got.Addr != "localhost:8080" || got.args[1] != "/tmp/x/config.yaml" || got.env["HOME"] != "/tmp/x" {
+		t.Errorf("expected the templates to be expanded, got %+v", got)
+	}
+	if tc := test.Case(0).(testcase); tc.args[1] != original.args[1] || tc.env["HOME"] != "{{.TmpDir}}" {
+		t.Errorf("expected the table to be left as it was, got %+v", tc)
+	}
+
+	missing := tbltest.Cases(testcase{Addr: "{{.Host}}"}).Expand(map[string]interface{}{})
+	res := missing.RunResult(func(tc testcase) {})
+	if res.Failed != 1 || !strings.Contains(res.Cases[0].Err.Error(), "Host") {
+		t.Errorf("expected the missing placeholder to fail the test case, got %+v", res.Cases)
+	}
+}
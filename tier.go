@@ -0,0 +1,80 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "flag"
+
+// Tier is how thorough a test case is, which decides whether it runs with go test -short.
+type Tier int
+
+const (
+	// TierStandard is the tier of the test cases that are not given one. They run with and without -short.
+	TierStandard Tier = iota
+	// TierSmoke is for the quick test cases that check the basics. They run with and without -short.
+	TierSmoke
+	// TierFull is for the slow or exhaustive test cases. They are left out of the run with -short.
+	TierFull
+)
+
+// String returns the name of the tier.
+func (t Tier) String() string {
+	switch t {
+	case TierStandard:
+		return "standard"
+	case TierSmoke:
+		return "smoke"
+	case TierFull:
+		return "full"
+	}
+	return "unknown"
+}
+
+// Tiered puts the test case in the given tier. Test cases in TierFull are not run when testing.Short reports
+// true, so go test -short trims the table to the smoke and standard test cases.
+//
+//	test := tbltest.Cases(
+//		tbltest.Tiered(testcase{input: ""}, tbltest.TierSmoke),
+//		testcase{input: "hello"},
+//		tbltest.Tiered(testcase{input: largeInput}, tbltest.TierFull),
+//	)
+func Tiered(tcase TestCase, tier Tier) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.tier = tier
+	})
+}
+
+// WithTier is an Option that puts the test cases in the given tier (see Tiered).
+func WithTier(tier Tier) Option {
+	return func(tcase TestCase) TestCase {
+		return Tiered(tcase, tier)
+	}
+}
+
+// Tier returns the tier of the test case at the given index.
+func (tc *Test) Tier(idx int) Tier {
+	if idx < 0 || idx >= len(tc.cases) {
+		return TierStandard
+	}
+	return tc.cases[idx].tier
+}
+
+// filterTier returns the indexes in list of the test cases that are run with -short.
+func (tc *Test) filterTier(list []int) []int {
+	var filtered []int
+	for _, idx := range list {
+		if idx >= 0 && idx < len(tc.cases) && tc.cases[idx].tier == TierFull {
+			continue
+		}
+		filtered = append(filtered, idx)
+	}
+	return filtered
+}
+
+// short reports whether the tests are run with -short, as testing.Short does, but without panicking when the
+// testing flags are not registered or parsed yet, such as in TestMain.
+func short() bool {
+	f := flag.Lookup("test.short")
+	return f != nil && f.Value.String() == "true"
+}
@@ -0,0 +1,43 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestTiered(t *testing.T) {
+	test := tbltest.Cases(
+		tbltest.Tiered(0, tbltest.TierSmoke),
+		1,
+		tbltest.Tiered(2, tbltest.TierFull),
+		tbltest.Group("slow", []tbltest.Option{tbltest.WithTier(tbltest.TierFull)}, 3),
+	)
+	if got := test.Tier(3); got != tbltest.TierFull {
+		t.Errorf("expected test case 3 to be in the full tier, got %v", got)
+	}
+	run := func() []int {
+		var ran []int
+		test.Run(func(tc int) { ran = append(ran, tc) })
+		sort.Ints(ran)
+		return ran
+	}
+
+	old := flag.Lookup("test.short").Value.String()
+	defer flag.Set("test.short", old)
+	flag.Set("test.short", "true")
+	if got, want := run(), []int{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with -short, expected the test cases %v to run, got %v", want, got)
+	}
+	flag.Set("test.short", "false")
+	if got, want := run(), []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("without -short, expected the test cases %v to run, got %v", want, got)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// tracing wraps call so that, when an execution trace is being recorded (e.g. with go test -trace), each test
+// case runs in a task and region of its own, named after the test function and the test case. So go tool trace
+// shows which test case the goroutines and time slices belong to.
+func (tc *Test) tracing(call CaseFunc) CaseFunc {
+	return func(idx int, tcase TestCase) (err error) {
+		if !trace.IsEnabled() || idx < 0 || idx >= len(tc.cases) {
+			return call(idx, tcase)
+		}
+		name := callerTestName() + "/" + tc.cases[idx].caseName(idx)
+		ctx, task := trace.NewTask(context.Background(), name)
+		defer task.End()
+		trace.WithRegion(ctx, name, func() {
+			err = call(idx, tcase)
+		})
+		return err
+	}
+}
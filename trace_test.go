@@ -0,0 +1,27 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestTraceRegions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("could not start tracing: %v", err)
+	}
+	tbltest.Cases(1, 2).Run(func(tc int) {})
+	trace.Stop()
+	for _, want := range []string{"TestTraceRegions/0", "TestTraceRegions/1"} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("expected a task and region named %v in the trace", want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"fmt"
+	"strings"
+)
+
+var warnDuplicates = flagBool("WarnDuplicates", false, "Log the test cases of each table that are identical to an earlier test case, before running it.")
+
+// Duplicate is a set of test cases that are identical to each other.
+type Duplicate struct {
+	// Indexes are the indexes of the identical test cases in the table, in order.
+	Indexes []int
+	// Sources are where each of the test cases was defined.
+	Sources []string
+}
+
+func (d Duplicate) String() string {
+	sites := make([]string, len(d.Indexes))
+	for i, idx := range d.Indexes {
+		sites[i] = fmt.Sprintf("%v (%v)", idx, d.Sources[i])
+	}
+	return "identical test cases " + strings.Join(sites, ", ")
+}
+
+// CheckUnique looks for test cases that are identical to each other, as decided by Equal, and logs and returns
+// them; large tables maintained by hand tend to pick up duplicates over time. The names, tags and markers of the
+// test cases are not compared, only their values. The test cases of Generate and Matrix that have not been run
+// yet are left out, as comparing them would generate every one of them.
+//
+//	if dups := test.CheckUnique(); len(dups) > 0 {
+//		t.Errorf("the table has %v sets of duplicate test cases", len(dups))
+//	}
+//
+// With the tblTest.WarnDuplicates option, each run of a table logs its duplicates before running it.
+func (tc *Test) CheckUnique() []Duplicate {
+	var dups []Duplicate
+	seen := make([]bool, len(tc.cases))
+	for i := range tc.cases {
+		if seen[i] || tc.cases[i].gen != nil {
+			continue
+		}
+		d := Duplicate{Indexes: []int{i}, Sources: []string{tc.cases[i].source}}
+		for j := i + 1; j < len(tc.cases); j++ {
+			if seen[j] || tc.cases[j].gen != nil {
+				continue
+			}
+			if Equal(tc.cases[i].value.Interface(), tc.cases[j].value.Interface()) {
+				seen[j] = true
+				d.Indexes = append(d.Indexes, j)
+				d.Sources = append(d.Sources, tc.cases[j].source)
+			}
+		}
+		if len(d.Indexes) > 1 {
+			logf("%v", d)
+			dups = append(dups, d)
+		}
+	}
+	return dups
+}
@@ -0,0 +1,47 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestCheckUnique(t *testing.T) {
+	type testcase struct {
+		in  []int
+		out int
+	}
+	test := tbltest.Cases(
+		testcase{in: []int{1, 2}, out: 3},
+		testcase{in: []int{2, 2}, out: 4},
+		testcase{in: []int{1, 2}, out: 3},
+	)
+	test.AddCases(testcase{in: []int{2, 2}, out: 4}, testcase{in: []int{1, 2}, out: 3})
+	dups := test.CheckUnique()
+	if len(dups) != 2 {
+		t.Fatalf("expected 2 sets of duplicates, got %v", dups)
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(dups[0].Indexes, want) {
+		t.Errorf("expected the first duplicates to be %v, got %v", want, dups[0].Indexes)
+	}
+	if want := []int{1, 3}; !reflect.DeepEqual(dups[1].Indexes, want) {
+		t.Errorf("expected the second duplicates to be %v, got %v", want, dups[1].Indexes)
+	}
+	for _, src := range dups[1].Sources {
+		if !strings.Contains(src, "unique_test.go:") {
+			t.Errorf("expected the duplicates to be defined in unique_test.go, got %v", src)
+		}
+	}
+	if dups[1].Sources[0] == dups[1].Sources[1] {
+		t.Errorf("expected the duplicates to have different definition sites, got %v", dups[1].Sources)
+	}
+	if dups := tbltest.Cases(1, 2, 3).CheckUnique(); dups != nil {
+		t.Errorf("expected no duplicates, got %v", dups)
+	}
+}
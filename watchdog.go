@@ -0,0 +1,36 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"runtime"
+	"time"
+)
+
+var watchdog = flagDuration("Watchdog", 0, "If not zero, log the stacks of all goroutines when a test case runs for longer than this, e.g. 1m, without stopping it.")
+
+// watch starts the watchdog of a test case, which logs the test case and the stacks of all the goroutines if it
+// runs for longer than d. The returned function stops the watchdog once the test case finished.
+func watch(d time.Duration, idx int, name string, start time.Time) (stop func()) {
+	if d <= 0 {
+		return func() {}
+	}
+	t := time.AfterFunc(d, func() {
+		logf("Test case %v (%v), started at %v, has been running for over %v; the goroutines are:\n%s", idx, name, start.Format(verboseTimeFormat), d, allStacks())
+	})
+	return func() { t.Stop() }
+}
+
+// allStacks returns the stacks of all the goroutines, as formatted by runtime.Stack.
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestWatchdog(t *testing.T) {
+	var buf syncBuffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	flag.Set("tblTest.Watchdog", "10ms")
+	defer flag.Set("tblTest.Watchdog", "0")
+
+	test := tbltest.Cases(tbltest.Named(50*time.Millisecond, "slow"), tbltest.Named(time.Duration(0), "fast"))
+	if count := test.Run(func(d time.Duration) { time.Sleep(d) }); count != 2 {
+		t.Errorf("expected to run 2 tests, ran %v instead", count)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Test case 0 (slow), started at ") || !strings.Contains(out, "has been running for over 10ms") {
+		t.Errorf("expected the slow test case to be logged, got %q", out)
+	}
+	if !strings.Contains(out, "goroutine ") || !strings.Contains(out, "TestWatchdog") {
+		t.Errorf("expected the stacks of the goroutines to be logged, got %q", out)
+	}
+	if strings.Contains(out, "(fast)") {
+		t.Errorf("expected the fast test case not to be logged, got %q", out)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var sample = flagInt("Sample", 0, "If not zero, only run this many test cases of each table, chosen at random, favoring the test cases with a larger weight; see tbltest.Weighted.")
+
+// Weighted gives the test case a weight, which is 1 by default. When the test cases are run in a random order, a
+// test case with a larger weight tends to run earlier, and is more likely to be chosen by the tblTest.Sample
+// option. So a large table, run with a budget such as tblTest.MaxDuration, spends most of it on the test cases
+// most likely to catch a regression.
+//
+//	test := tbltest.Cases(
+//		tbltest.Weighted(testcase{input: fuzzCorpus[0]}, 10),
+//		...
+//	)
+func Weighted(tcase TestCase, weight float64) TestCase {
+	if !(weight > 0) {
+		panicf("The weight of a test case should be greater than zero, was given %v", weight)
+	}
+	return mark(tcase, func(e *entry) {
+		e.weight = weight
+	})
+}
+
+// weighted reports whether any of the test cases were given a weight.
+func (tc *Test) weighted() bool {
+	for i := range tc.cases {
+		if tc.cases[i].weight != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// weightedPerm returns list in a random order, where each next test case is chosen with a probability
+// proportional to its weight among those left.
+func (tc *Test) weightedPerm(r *rand.Rand, list []int) []int {
+	// Ordering by an exponentially distributed key, with a rate of the weight, is the same as drawing the test
+	// cases one at a time by weight.
+	keys := make([]float64, len(list))
+	for i, idx := range list {
+		w := 1.0
+		if idx >= 0 && idx < len(tc.cases) && tc.cases[idx].weight != 0 {
+			w = tc.cases[idx].weight
+		}
+		keys[i] = -math.Log(1-r.Float64()) / w
+	}
+	perm := byKey{idxs: append([]int(nil), list...), keys: keys}
+	sort.Sort(perm)
+	return perm.idxs
+}
+
+// sampleCases returns n of the test cases in list, chosen by weight, in the order of list.
+func (tc *Test) sampleCases(list []int, n int, seed int64) []int {
+	if n >= len(list) {
+		return list
+	}
+	if seed == 0 {
+		seed = newSeed()
+	}
+	chosen := make(map[int]bool, n)
+	for _, idx := range tc.weightedPerm(rand.New(rand.NewSource(seed)), list)[:n] {
+		chosen[idx] = true
+	}
+	sampled := make([]int, 0, n)
+	for _, idx := range list {
+		if chosen[idx] {
+			sampled = append(sampled, idx)
+		}
+	}
+	logf("Sampled %v of %v test cases with seed %v; use -%v=%[3]v to reproduce.", n, len(list), seed, flagName("Seed"))
+	return sampled
+}
+
+// byKey sorts indexes by their keys.
+type byKey struct {
+	idxs []int
+	keys []float64
+}
+
+func (b byKey) Len() int           { return len(b.idxs) }
+func (b byKey) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKey) Swap(i, j int) {
+	b.idxs[i], b.idxs[j] = b.idxs[j], b.idxs[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
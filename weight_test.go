@@ -0,0 +1,55 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestWeighted(t *testing.T) {
+	// Over many shuffles, the heavy test case should run first far more often than any of the others.
+	test := tbltest.Cases(0, 1, 2, tbltest.Weighted(3, 100), 4).WithRand(rand.New(rand.NewSource(1)))
+	first := make(map[int]int)
+	for i := 0; i < 200; i++ {
+		ran := false
+		test.Run(func(tc int) {
+			if !ran {
+				first[tc]++
+				ran = true
+			}
+		})
+	}
+	if first[3] < 150 {
+		t.Errorf("expected the weighted test case to run first most of the time, got %v", first)
+	}
+}
+
+func TestSample(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	flag.Set("tblTest.Sample", "2")
+	defer flag.Set("tblTest.Sample", "0")
+	test := tbltest.Cases(0, 1, 2, 3, 4, 5)
+	test.InOrder = true
+	var ran []int
+	if n := test.Run(func(tc int) { ran = append(ran, tc) }); n != 2 {
+		t.Errorf("expected 2 test cases to run, got %v", n)
+	}
+	if len(ran) != 2 || ran[0] >= ran[1] {
+		t.Errorf("expected the sampled test cases to run in order, got %v", ran)
+	}
+	if !strings.Contains(buf.String(), "Sampled 2 of 6 test cases with seed ") {
+		t.Errorf("expected the sample to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest
+
+import "fmt"
+
+// ExpectFail marks the test case as known to fail, for the given reason. This keeps a regression case in the
+// table while the fix is pending.
+//
+// For a test case marked this way, the test function failing (returning false, or panicking) is expected and
+// the run continues. If instead the test function passes, the unexpected pass is logged and treated as a
+// failure, as a reminder to remove the mark now that the underlying bug is fixed.
+//
+//	tbltest.ExpectFail(testcase{input: "\x00"}, "issue #12: nul bytes are not escaped")
+func ExpectFail(tcase TestCase, reason string) TestCase {
+	return mark(tcase, func(e *entry) {
+		e.expectFail = true
+		e.xfailReason = reason
+	})
+}
+
+// runExpectedFailure runs a test case marked with ExpectFail. The returned error is not nil if the test case
+// passed unexpectedly.
+func runExpectedFailure(call CaseFunc, idx int, e *entry) error {
+	passed := func() (passed bool) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				passed = false
+			}
+		}()
+		return call(idx, e.get().Interface()) == nil
+	}()
+	if passed {
+		logf("Test case %v passed unexpectedly; it is marked as expected to fail: %v", idx, e.xfailReason)
+		return fmt.Errorf("passed, but is marked as expected to fail: %v", e.xfailReason)
+	}
+	logf("Test case %v failed as expected: %v", idx, e.xfailReason)
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Gautam Dey. All rights reserved.
+// Use of this source code is governed by FreeBDS License (2-clause Simplified BSD.)
+// that can be found in the LICENSE file.
+
+package tbltest_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdey/tbltest"
+)
+
+func TestExpectFail(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	test := tbltest.Cases(
+		0,
+		tbltest.ExpectFail(1, "returns false"),
+		tbltest.ExpectFail(2, "panics"),
+		3,
+	)
+	test.InOrder = true
+	count := test.Run(func(tc int) bool {
+		if tc == 2 {
+			panic("boom")
+		}
+		return tc != 1
+	})
+	if count != 4 {
+		t.Errorf("expected expected failures not to stop the run, ran %v tests", count)
+	}
+	if strings.Contains(buf.String(), "unexpectedly") {
+		t.Errorf("did not expect an unexpected pass, got log: %v", buf.String())
+	}
+
+	buf.Reset()
+	test = tbltest.Cases(tbltest.ExpectFail(0, "fixed now"), 1)
+	test.InOrder = true
+	count = test.Run(func(tc int) bool { return true })
+	if count != 1 {
+		t.Errorf("expected an unexpected pass to stop the run, ran %v tests", count)
+	}
+	if !strings.Contains(buf.String(), "passed unexpectedly") || !strings.Contains(buf.String(), "fixed now") {
+		t.Errorf("expected the unexpected pass to be logged, got log: %v", buf.String())
+	}
+}